@@ -0,0 +1,110 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Catalog is a set of error definitions keyed by code, so a program's errors
+// are declared, documented and numbered in one place:
+//
+//	catalog := horus.NewCatalog()
+//	catalog.Register(&horus.Sentinel{
+//		Code:     "CFG_MISSING",
+//		Category: "config",
+//		ExitCode: horus.ExitConfig,
+//		Template: "config file {path} not found",
+//		HelpURL:  "https://example.com/errors/CFG_MISSING",
+//	})
+//	err := catalog.New("CFG_MISSING", "load config", map[string]any{"path": p})
+//
+// Instances carry the definition: CheckErr exits with its ExitCode, the HTTP
+// responder answers with its HTTPStatus, and the formatters show its code and
+// help link. It is safe for concurrent use.
+type Catalog struct {
+	mu   sync.RWMutex
+	defs map[string]*Sentinel
+}
+
+// NewCatalog returns an empty Catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{defs: make(map[string]*Sentinel)}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Register adds definitions to the catalog. It fails, registering none of
+// them, if a definition has no code or its code is already taken.
+func (c *Catalog) Register(defs ...*Sentinel) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seen := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		if def == nil || def.Code == "" {
+			return NewCategorizedHerror("register error", "usage", "error definition without a code", nil, nil)
+		}
+		if _, taken := c.defs[def.Code]; taken || seen[def.Code] {
+			return NewCategorizedHerror("register error", "usage", fmt.Sprintf("error code %q already registered", def.Code), nil, map[string]any{"code": def.Code})
+		}
+		seen[def.Code] = true
+	}
+	for _, def := range defs {
+		c.defs[def.Code] = def
+	}
+	return nil
+}
+
+// Lookup returns the definition registered under code.
+func (c *Catalog) Lookup(code string) (*Sentinel, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	def, ok := c.defs[code]
+	return def, ok
+}
+
+// Codes returns the registered codes, sorted.
+func (c *Catalog) Codes() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	codes := make([]string, 0, len(c.defs))
+	for code := range c.defs {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// New creates an instance of the definition registered under code, like
+// Sentinel.New. An unknown code still yields an Herror carrying that code,
+// with a message saying it is not in the catalog.
+func (c *Catalog) New(code, op string, details map[string]any) error {
+	return c.lookupOrUnknown(code).instance(op, nil, details)
+}
+
+// Wrap creates an instance of the definition registered under code caused by
+// err, like Sentinel.Wrap. It returns nil if err is nil.
+func (c *Catalog) Wrap(err error, code, op string, details map[string]any) error {
+	if err == nil {
+		return nil
+	}
+	return c.lookupOrUnknown(code).instance(op, err, details)
+}
+
+func (c *Catalog) lookupOrUnknown(code string) *Sentinel {
+	if def, ok := c.Lookup(code); ok {
+		return def
+	}
+	return &Sentinel{Code: code, Message: fmt.Sprintf("unknown error code %q", code)}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
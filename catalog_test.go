@@ -0,0 +1,133 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func testCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	c := NewCatalog()
+	err := c.Register(
+		&Sentinel{
+			Code:       "CFG_MISSING",
+			Category:   "config",
+			ExitCode:   ExitConfig,
+			HTTPStatus: http.StatusUnprocessableEntity,
+			Template:   "config file {path} not found (profile {profile})",
+			HelpURL:    "https://example.com/errors/CFG_MISSING",
+		},
+		Define("PLAIN", "plain failure"),
+	)
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	return c
+}
+
+func TestCatalog_Register(t *testing.T) {
+	c := testCatalog(t)
+	if got := strings.Join(c.Codes(), ","); got != "CFG_MISSING,PLAIN" {
+		t.Errorf("Codes = %s", got)
+	}
+	if err := c.Register(Define("NEW", "x"), Define("PLAIN", "again")); err == nil {
+		t.Error("duplicate code should be rejected")
+	}
+	if _, ok := c.Lookup("NEW"); ok {
+		t.Error("a failed Register must not add any definition")
+	}
+	if err := c.Register(&Sentinel{Message: "no code"}); err == nil {
+		t.Error("definition without a code should be rejected")
+	}
+}
+
+func TestCatalog_NewTemplate(t *testing.T) {
+	c := testCatalog(t)
+	err := c.New("CFG_MISSING", "load config", map[string]any{"path": "/etc/app.yaml", "profile_token": "x"})
+	h, _ := AsHerror(err)
+	if h.Message != "config file /etc/app.yaml not found (profile {profile})" {
+		t.Errorf("Message = %q", h.Message)
+	}
+	if h.Category != "config" || h.Code != "CFG_MISSING" || !strings.Contains(h.StackTrace(), "catalog_test.go") {
+		t.Errorf("instance = %+v", h)
+	}
+	if url, ok := HelpURL(Wrap(err, "startup", "cannot start")); !ok || url != "https://example.com/errors/CFG_MISSING" {
+		t.Errorf("HelpURL through Wrap = %q, %v", url, ok)
+	}
+	def, _ := c.Lookup("CFG_MISSING")
+	if !errors.Is(PropagateErr("main", "", "failed", err, nil), def) {
+		t.Error("catalog instances should match their definition")
+	}
+
+	// templates never render redacted values
+	tmpl := &Sentinel{Code: "AUTH", Template: "login failed with {password}"}
+	if got, _ := UserMessage(tmpl.New("login", map[string]any{"password": "hunter2"})); got != "login failed with [REDACTED]" {
+		t.Errorf("template message = %q", got)
+	}
+
+	unknown, _ := AsHerror(c.New("NOPE", "x", nil))
+	if unknown.Code != "NOPE" || !strings.Contains(unknown.Message, "unknown error code") {
+		t.Errorf("unknown code instance = %+v", unknown)
+	}
+	if c.Wrap(nil, "PLAIN", "x", nil) != nil || !errors.Is(c.Wrap(io.EOF, "PLAIN", "read", nil), io.EOF) {
+		t.Error("Catalog.Wrap should return nil for nil and keep the cause")
+	}
+}
+
+func TestCatalog_ExitCodeAndHTTP(t *testing.T) {
+	c := testCatalog(t)
+	err := c.New("CFG_MISSING", "load config", map[string]any{"path": "a"})
+
+	code := 0
+	r := NewReporter(WithWriter(io.Discard), WithExitFunc(func(c int) { code = c }))
+	r.CheckErr(err)
+	if code != ExitConfig {
+		t.Errorf("exit code = %d; want %d", code, ExitConfig)
+	}
+	r.CheckErr(err, WithExitCode(3))
+	if code != 3 {
+		t.Errorf("explicit exit code = %d; want 3", code)
+	}
+
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, httptest.NewRequest("GET", "/", nil), err)
+	var p map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &p)
+	if rec.Code != http.StatusUnprocessableEntity || p["type"] != "https://example.com/errors/CFG_MISSING" || p["code"] != "CFG_MISSING" {
+		t.Errorf("problem = %d %v", rec.Code, p)
+	}
+}
+
+func TestCatalog_Formatters(t *testing.T) {
+	t.Setenv("COLUMNS", "") // keep the default width
+	c := testCatalog(t)
+	h, _ := AsHerror(c.New("CFG_MISSING", "load config", map[string]any{"path": "a"}))
+
+	pseudo := stripANSI(PseudoJSONFormatter(h))
+	if !strings.Contains(pseudo, "Code     CFG_MISSING,") || !strings.Contains(pseudo, "Help     https://example.com/errors/CFG_MISSING,") {
+		t.Errorf("PseudoJSONFormatter missing code or help:\n%s", pseudo)
+	}
+
+	var m map[string]any
+	json.Unmarshal([]byte(JSONFormatter(h)), &m)
+	if m["Code"] != "CFG_MISSING" || m["Help"] != "https://example.com/errors/CFG_MISSING" {
+		t.Errorf("JSONFormatter = %v", m)
+	}
+	decoded, err := DecodeHerror([]byte(JSONFormatter(h)))
+	if err != nil || decoded.HelpURL() != "https://example.com/errors/CFG_MISSING" {
+		t.Errorf("help link lost in JSON round trip: %v", err)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,138 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OpPathSeparator joins the operations returned by Ops in OpPath.
+const OpPathSeparator = " > "
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Chain returns every layer of err's causal chain, outermost first, ending
+// with the root cause. Where the chain branches (errors.Join, MultiError) it
+// follows the first branch, like RootCause; use Walk for the whole tree.
+func Chain(err error) []error {
+	var layers []error
+	for err != nil {
+		layers = append(layers, err)
+		children := unwrapAll(err)
+		if len(children) == 0 {
+			break
+		}
+		err = children[0]
+	}
+	return layers
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RootCauseOption customizes RootCause.
+type RootCauseOption func(*rootCauseConfig)
+
+type rootCauseConfig struct {
+	stopAtHerror bool
+}
+
+// StopAtHerror makes RootCause return the innermost *Herror of the chain
+// instead of the raw error below it, so its Op, Category and Details stay
+// available. Chains without an Herror still yield the raw root cause.
+func StopAtHerror() RootCauseOption {
+	return func(cfg *rootCauseConfig) {
+		cfg.stopAtHerror = true
+	}
+}
+
+// RootCause returns the innermost error of err's chain. Where the chain
+// branches (errors.Join, MultiError) it follows the first branch; use
+// RootCauses for every leaf.
+func RootCause(err error, opts ...RootCauseOption) error {
+	var cfg rootCauseConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	layers := Chain(err)
+	if len(layers) == 0 {
+		return nil
+	}
+	if cfg.stopAtHerror {
+		for i := len(layers) - 1; i >= 0; i-- {
+			if _, ok := layers[i].(*Herror); ok {
+				return layers[i]
+			}
+		}
+	}
+	return layers[len(layers)-1]
+}
+
+// RootCauseHelper returns the innermost error of err's chain.
+//
+// Deprecated: use RootCause.
+func RootCauseHelper(err error) error {
+	return RootCause(err)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// FindHerror returns the first *Herror in err's tree, depth first, for which
+// match returns true.
+func FindHerror(err error, match func(*Herror) bool) (*Herror, bool) {
+	var found *Herror
+	Walk(err, func(e error, _ int) bool {
+		if found != nil {
+			return false
+		}
+		if h, ok := e.(*Herror); ok && match(h) {
+			found = h
+		}
+		return found == nil
+	})
+	return found, found != nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Ops returns the operation of every Herror layer in err's chain, outermost
+// first, e.g. ["load config", "read file", "open"]. Empty ops are skipped.
+func Ops(err error) []string {
+	var ops []string
+	for _, layer := range Chain(err) {
+		if h, ok := layer.(*Herror); ok && h.Op != "" {
+			ops = append(ops, h.Op)
+		}
+	}
+	return ops
+}
+
+// OpPath returns Ops joined with OpPathSeparator, e.g.
+// "load config > read file > open".
+func OpPath(err error) string {
+	return strings.Join(Ops(err), OpPathSeparator)
+}
+
+// FirstOp returns the operation of the outermost Herror layer.
+func FirstOp(err error) (string, bool) {
+	ops := Ops(err)
+	if len(ops) == 0 {
+		return "", false
+	}
+	return ops[0], true
+}
+
+// LastOp returns the operation of the innermost Herror layer, where the
+// failure originated.
+func LastOp(err error) (string, bool) {
+	ops := Ops(err)
+	if len(ops) == 0 {
+		return "", false
+	}
+	return ops[len(ops)-1], true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
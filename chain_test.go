@@ -0,0 +1,88 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func sampleChain() error {
+	err := NewCategorizedHerror("open", "io", "cannot open", fs.ErrNotExist, nil)
+	err = fmt.Errorf("retrying: %w", err)
+	err = Wrap(err, "read file", "read failed")
+	return Wrap(err, "load config", "config unavailable")
+}
+
+func TestChain(t *testing.T) {
+	layers := Chain(sampleChain())
+	if len(layers) != 5 {
+		t.Fatalf("Chain has %d layers; want 5: %v", len(layers), layers)
+	}
+	if layers[len(layers)-1] != fs.ErrNotExist {
+		t.Errorf("last layer = %v; want the raw root cause", layers[len(layers)-1])
+	}
+	if Chain(nil) != nil {
+		t.Error("Chain(nil) should be nil")
+	}
+}
+
+func TestRootCause_StopAtHerror(t *testing.T) {
+	err := sampleChain()
+	if RootCause(err) != fs.ErrNotExist {
+		t.Errorf("RootCause = %v; want fs.ErrNotExist", RootCause(err))
+	}
+	h, ok := RootCause(err, StopAtHerror()).(*Herror)
+	if !ok || h.Op != "open" {
+		t.Errorf("RootCause(StopAtHerror) = %v; want the 'open' Herror", RootCause(err, StopAtHerror()))
+	}
+	plain := fmt.Errorf("x: %w", fs.ErrClosed)
+	if RootCause(plain, StopAtHerror()) != fs.ErrClosed {
+		t.Error("without an Herror StopAtHerror should yield the raw root cause")
+	}
+	if RootCause(nil) != nil || RootCauseHelper(err) != fs.ErrNotExist {
+		t.Error("RootCause(nil) or RootCauseHelper mismatch")
+	}
+}
+
+func TestFindHerror(t *testing.T) {
+	err := errors.Join(errors.New("a"), sampleChain())
+	h, ok := FindHerror(err, func(h *Herror) bool { return h.Category == "io" })
+	if !ok || h.Op != "load config" {
+		t.Errorf("FindHerror = %v, %v; want the outermost io layer", h, ok)
+	}
+	h, ok = FindHerror(err, func(h *Herror) bool { return h.Op == "open" })
+	if !ok || h.Message != "cannot open" {
+		t.Errorf("FindHerror(open) = %v, %v", h, ok)
+	}
+	if _, ok := FindHerror(err, func(*Herror) bool { return false }); ok {
+		t.Error("FindHerror should report no match")
+	}
+}
+
+func TestOps(t *testing.T) {
+	err := sampleChain()
+	if got := OpPath(err); got != "load config > read file > open" {
+		t.Errorf("OpPath = %q", got)
+	}
+	if op, ok := FirstOp(err); !ok || op != "load config" {
+		t.Errorf("FirstOp = %q, %v", op, ok)
+	}
+	if op, ok := LastOp(err); !ok || op != "open" {
+		t.Errorf("LastOp = %q, %v", op, ok)
+	}
+	if _, ok := FirstOp(errors.New("plain")); ok {
+		t.Error("FirstOp should fail without an Herror")
+	}
+	if _, ok := LastOp(nil); ok || Ops(nil) != nil || OpPath(nil) != "" {
+		t.Error("nil error should have no ops")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
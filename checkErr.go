@@ -5,49 +5,28 @@ package horus
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 import (
-	"errors"
-	"fmt"
 	"io"
 	"os"
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// exitFunc is called by CheckErr to terminate the process.
-// Override in tests if you want to capture the exit code.
-var exitFunc = os.Exit
-
-// errorTypeRegistry tracks how many errors of each Category have been seen.
-var errorTypeRegistry = make(map[string]int)
-
-////////////////////////////////////////////////////////////////////////////////////////////////////
-
-// RegisterError increments the count for this error’s category.
+// RegisterError records err in the default Reporter's registry.
 func RegisterError(err error) {
-	if err == nil {
-		return
-	}
-	if herr, ok := AsHerror(err); ok && herr.Category != "" {
-		errorTypeRegistry[herr.Category]++
-	} else {
-		errorTypeRegistry["unknown"]++
-	}
+	Default().RegisterError(err)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetErrorRegistry returns a copy of the current error counts.
+// GetErrorRegistry returns a copy of the default Reporter's per-category
+// error counts. Use Default().Registry().Snapshot() for the full breakdown.
 func GetErrorRegistry() map[string]int {
-	copyMap := make(map[string]int, len(errorTypeRegistry))
-	for k, v := range errorTypeRegistry {
-		copyMap[k] = v
-	}
-	return copyMap
+	return Default().ErrorRegistry()
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// checkOpt is the functional-option type for CheckErr.
+// checkOpt is the functional-option type for CheckErr and NewReporter.
 type checkOpt func(*checkParams)
 
 // checkParams holds all configurable values for CheckErr.
@@ -55,10 +34,80 @@ type checkParams struct {
 	op        string
 	category  string
 	message   string
+	msgSet    bool // message was chosen explicitly with WithMessage
 	details   map[string]any
+	detSet    bool // details were chosen explicitly with WithDetails
+	severity  Severity
+	sevSet    bool     // severity was chosen explicitly with WithSeverity
+	threshold Severity // minimum severity at which the exit policy applies
 	writer    io.Writer
 	exitCode  int
+	exitSet   bool // exitCode was chosen explicitly with WithExitCode
+	exitCodes *ExitCodeTable
+	policy    ExitPolicy
 	formatter FormatterFunc
+	registry  *Registry
+	stack     *StackConfig // nil means the package-wide GetStackConfig()
+}
+
+// defaultCheckParams returns the values CheckErr uses when nothing is overridden.
+func defaultCheckParams() checkParams {
+	return checkParams{
+		op:        "check error",
+		category:  "runtime_error",
+		message:   "An error occurred during execution",
+		details:   map[string]any{"location": "checkErr"},
+		severity:  SeverityCritical,
+		writer:    os.Stderr,
+		exitCode:  1,
+		exitCodes: DefaultExitCodes(),
+		policy:    ExitProcess(os.Exit),
+		formatter: PseudoJSONFormatter,
+		registry:  NewRegistry(),
+	}
+}
+
+// stackConfig returns the stack configuration in effect for these params.
+func (p *checkParams) stackConfig() StackConfig {
+	if p.stack != nil {
+		return *p.stack
+	}
+	return GetStackConfig()
+}
+
+// resolveExitCode picks the exit code for herr: an explicit WithExitCode
+// wins, then the ExitCode of the error's definition (see Catalog), then the
+// exit code table, then the fallback exitCode.
+func (p *checkParams) resolveExitCode(herr *Herror) int {
+	if !p.exitSet {
+		if def := definition(herr); def != nil && def.ExitCode != 0 {
+			return def.ExitCode
+		}
+		if code, ok := p.exitCodes.Lookup(herr); ok {
+			return code
+		}
+	}
+	return p.exitCode
+}
+
+// resolveSeverity picks the severity of the reported error: an explicit
+// WithSeverity wins, then the severity carried by err, then the default.
+func (p *checkParams) resolveSeverity(err error) Severity {
+	if !p.sevSet {
+		if s := SeverityOf(err); s != SeverityUnset {
+			return s
+		}
+	}
+	return p.severity
+}
+
+// finish applies the exit policy to the reported herr, unless its severity is
+// below the exit threshold, in which case herr is returned as is.
+func (p *checkParams) finish(herr *Herror) error {
+	if herr.Severity < p.threshold {
+		return herr
+	}
+	return p.policy(herr, p.resolveExitCode(herr))
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -81,6 +130,7 @@ func WithCategory(cat string) checkOpt {
 func WithMessage(msg string) checkOpt {
 	return func(p *checkParams) {
 		p.message = msg
+		p.msgSet = true
 	}
 }
 
@@ -89,20 +139,84 @@ func WithMessage(msg string) checkOpt {
 func WithDetails(d map[string]any) checkOpt {
 	return func(p *checkParams) {
 		p.details = d
+		p.detSet = true
 	}
 }
 
-// WithWriter redirects the error output (defaults to stderr).
+// WithWriter redirects the error output (defaults to stderr). Colors are
+// stripped unless ColorEnabled(w).
 func WithWriter(w io.Writer) checkOpt {
 	return func(p *checkParams) {
 		p.writer = w
 	}
 }
 
-// WithExitCode sets a custom exit code (defaults to 1).
+// WithExitCode sets a custom exit code. It takes precedence over the exit
+// code table; without it the table is consulted and 1 is the fallback.
 func WithExitCode(code int) checkOpt {
 	return func(p *checkParams) {
 		p.exitCode = code
+		p.exitSet = true
+	}
+}
+
+// WithSeverity sets the severity of the reported error, overriding the one
+// carried by the error itself (CheckErr defaults to SeverityCritical).
+func WithSeverity(s Severity) checkOpt {
+	return func(p *checkParams) {
+		p.severity = s
+		p.sevSet = true
+	}
+}
+
+// WithExitThreshold makes CheckErr apply the exit policy only to errors at or
+// above severity s; less severe errors are reported and execution continues.
+// By default every error exits.
+func WithExitThreshold(s Severity) checkOpt {
+	return func(p *checkParams) {
+		p.threshold = s
+	}
+}
+
+// WithExitCodes sets the table mapping categories and root causes to exit
+// codes (defaults to DefaultExitCodes). A nil table always falls back to 1.
+func WithExitCodes(t *ExitCodeTable) checkOpt {
+	return func(p *checkParams) {
+		p.exitCodes = t
+	}
+}
+
+// WithExitFunc replaces the function used to terminate the process
+// (defaults to os.Exit). Useful in tests to capture the exit code.
+// It is shorthand for WithExitPolicy(ExitProcess(f)).
+func WithExitFunc(f func(int)) checkOpt {
+	return func(p *checkParams) {
+		p.policy = ExitProcess(f)
+	}
+}
+
+// WithExitPolicy chooses what happens after the error has been reported:
+// ExitProcess, PanicPolicy, ReturnPolicy or CallbackPolicy.
+func WithExitPolicy(policy ExitPolicy) checkOpt {
+	return func(p *checkParams) {
+		p.policy = policy
+	}
+}
+
+// WithRegistry sets the Registry errors are counted into. Given to
+// NewReporter it replaces the Reporter's own registry; given to a single
+// CheckErr call it redirects only that call. A nil Registry disables counting.
+func WithRegistry(reg *Registry) checkOpt {
+	return func(p *checkParams) {
+		p.registry = reg
+	}
+}
+
+// WithStackConfig sets how the Herror built by CheckErr captures its stack,
+// overriding the package-wide SetStackConfig for this Reporter or call.
+func WithStackConfig(cfg StackConfig) checkOpt {
+	return func(p *checkParams) {
+		p.stack = &cfg
 	}
 }
 
@@ -122,61 +236,32 @@ func WithFormatter(f FormatterFunc) checkOpt {
 //	errMsg  – the error text to wrap (e.g. "`--script` is required")
 //	opts    – any CheckErr options (WithOp, WithMessage, etc.)
 func CheckEmpty(val, errMsg string, opts ...checkOpt) {
-	if val == "" {
-		CheckErr(
-			errors.New(errMsg),
-			opts...,
-		)
-	}
+	Default().CheckEmpty(val, errMsg, opts...)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// CheckErr registers, wraps, formats and logs a fatal error via Horus.
-// If err is non-nil it prints using the configured FormatterFunc, then exits.
+// CheckErr registers, wraps, formats and logs a fatal error through the
+// default Reporter. If err is non-nil it prints using the configured
+// FormatterFunc, then applies the exit policy (os.Exit by default).
 func CheckErr(err error, opts ...checkOpt) {
-	if err == nil {
-		return
-	}
-
-	// 1) metrics / instrumentation
-	RegisterError(err)
-
-	// 2) default parameters
-	cfg := checkParams{
-		op:        "check error",
-		category:  "runtime_error",
-		message:   "An error occurred during execution",
-		details:   map[string]any{"severity": "critical", "location": "checkErr"},
-		writer:    os.Stderr,
-		exitCode:  1,
-		formatter: PseudoJSONFormatter,
-	}
+	Default().CheckErr(err, opts...)
+}
 
-	// 3) apply user overrides
-	for _, opt := range opts {
-		opt(&cfg)
-	}
+////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	// 4) build a rich *Herror
-	herr := NewCategorizedHerror(
-		cfg.op,
-		cfg.category,
-		cfg.message,
-		err,
-		cfg.details,
-	)
+// Report runs the same pipeline as CheckErr through the default Reporter but
+// does not terminate the process unless told to: it returns the reported
+// *Herror (or nil when err is nil).
+func Report(err error, opts ...checkOpt) error {
+	return Default().Report(err, opts...)
+}
 
-	// 5) format & print
-	if he, ok := AsHerror(herr); ok {
-		fmt.Fprintln(cfg.writer, cfg.formatter(he))
-	} else {
-		// shouldn't happen, but fallback to plain Error()
-		fmt.Fprintln(cfg.writer, herr.Error())
-	}
+////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	// 6) exit
-	exitFunc(cfg.exitCode)
+// Warn reports err as a warning through the default Reporter and never exits.
+func Warn(err error, opts ...checkOpt) {
+	Default().Warn(err, opts...)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
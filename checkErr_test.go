@@ -14,13 +14,13 @@ import (
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func TestRegisterErrorAndGetErrorRegistry(t *testing.T) {
-	// reset the global registry
-	errorTypeRegistry = make(map[string]int)
+	// start from a fresh default reporter
+	defer SetDefault(SetDefault(NewReporter()))
 
 	// nil error should be ignored
 	RegisterError(nil)
-	if len(errorTypeRegistry) != 0 {
-		t.Fatalf("expected empty registry after RegisterError(nil), got %v", errorTypeRegistry)
+	if reg := GetErrorRegistry(); len(reg) != 0 {
+		t.Fatalf("expected empty registry after RegisterError(nil), got %v", reg)
 	}
 
 	// plain error → "unknown"
@@ -47,11 +47,9 @@ func TestRegisterErrorAndGetErrorRegistry(t *testing.T) {
 }
 
 func TestCheckErr_DefaultBehavior(t *testing.T) {
-	// override exitFunc to capture the code
-	origExit := exitFunc
+	// override the exit function to capture the code
 	var code int
-	exitFunc = func(c int) { code = c }
-	defer func() { exitFunc = origExit }()
+	defer SetDefault(SetDefault(NewReporter(WithExitFunc(func(c int) { code = c }))))
 
 	// capture output
 	buf := &bytes.Buffer{}
@@ -72,8 +70,8 @@ func TestCheckErr_DefaultBehavior(t *testing.T) {
 		"boom",          // original error
 		"check error",   // default op
 		"runtime_error", // default category
-		"severity",      // default detail key
-		"critical",      // default detail value
+		"Severity",      // default severity field
+		"critical",      // default severity
 	} {
 		if !strings.Contains(out, want) {
 			t.Errorf("output %q missing %q", out, want)
@@ -82,11 +80,9 @@ func TestCheckErr_DefaultBehavior(t *testing.T) {
 }
 
 func TestCheckErr_WithOverrides(t *testing.T) {
-	// override exitFunc
-	origExit := exitFunc
+	// override the exit function to capture the code
 	var code int
-	exitFunc = func(c int) { code = c }
-	defer func() { exitFunc = origExit }()
+	defer SetDefault(SetDefault(NewReporter(WithExitFunc(func(c int) { code = c }))))
 
 	// capture output
 	buf := &bytes.Buffer{}
@@ -0,0 +1,268 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/DanielRivasMD/horus/internal/ansi"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorMode selects when horus emits ANSI colors.
+type ColorMode int
+
+const (
+	// ColorAuto colors output written to a terminal, honoring the NO_COLOR
+	// and FORCE_COLOR environment variables. This is the default.
+	ColorAuto ColorMode = iota
+	// ColorAlways colors every output, terminal or not.
+	ColorAlways
+	// ColorNever never colors: formatters return plain text.
+	ColorNever
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var colorMode atomic.Int32
+
+// SetColorMode replaces the package-wide ColorMode and returns the previous one.
+func SetColorMode(m ColorMode) ColorMode {
+	return ColorMode(colorMode.Swap(int32(m)))
+}
+
+// GetColorMode returns the package-wide ColorMode.
+func GetColorMode() ColorMode {
+	return ColorMode(colorMode.Load())
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// colorsAllowed reports whether styles are applied at all. They are not under
+// ColorNever, nor under ColorAuto when NO_COLOR is set and FORCE_COLOR is not.
+func colorsAllowed() bool {
+	switch GetColorMode() {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	return forceColor() || os.Getenv("NO_COLOR") == ""
+}
+
+// forceColor reports whether FORCE_COLOR asks for colors ("0" and "false" do not).
+func forceColor() bool {
+	v := strings.ToLower(os.Getenv("FORCE_COLOR"))
+	return v != "" && v != "0" && v != "false"
+}
+
+// ColorEnabled reports whether output written to w should be colored: always
+// under ColorAlways, never under ColorNever, and under ColorAuto when w is a
+// terminal (or FORCE_COLOR is set) and NO_COLOR is not set.
+func ColorEnabled(w io.Writer) bool {
+	if !colorsAllowed() {
+		return false
+	}
+	if GetColorMode() == ColorAlways || forceColor() {
+		return true
+	}
+	return isTerminal(w)
+}
+
+// isTerminal reports whether w is a character device other than a dumb
+// terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// StripANSI removes ANSI escape sequences from s.
+func StripANSI(s string) string {
+	return ansi.Strip(s)
+}
+
+// NewColorWriter returns w itself when ColorEnabled(w), and otherwise a writer
+// that strips ANSI escapes before writing to w. CheckErr, LogNotFound and the
+// HTTP responders write through it, so formatted output only keeps its colors
+// on a terminal. Escape sequences split across two Write calls are not
+// recognized.
+func NewColorWriter(w io.Writer) io.Writer {
+	if ColorEnabled(w) {
+		return w
+	}
+	return &stripWriter{w: w}
+}
+
+type stripWriter struct {
+	w io.Writer
+}
+
+// Write strips escapes from p and reports p as fully written on success.
+func (s *stripWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(s.w, StripANSI(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Style decorates text, typically by wrapping it in ANSI escapes. A nil Style
+// leaves text unchanged.
+type Style func(string) string
+
+// Color16 returns a Style with one of the 16 basic ANSI colors: 0-7 are black,
+// red, green, yellow, blue, magenta, cyan and white, 8-15 their bright variants.
+func Color16(n int) Style {
+	return ansi.Color16(n).Paint
+}
+
+// Color256 returns a Style with color n of the 256-color palette.
+func Color256(n uint8) Style {
+	return ansi.Color256(n).Paint
+}
+
+// TrueColor returns a Style with a 24-bit RGB color.
+func TrueColor(r, g, b uint8) Style {
+	return ansi.RGB(r, g, b).Paint
+}
+
+// Bold returns a bold Style.
+func Bold() Style {
+	return ansi.Bold.Paint
+}
+
+// Dim returns a dimmed Style.
+func Dim() Style {
+	return ansi.Dim.Paint
+}
+
+// Underline returns an underlined Style.
+func Underline() Style {
+	return ansi.Underline.Paint
+}
+
+// Combine returns a Style applying styles from the outermost to the
+// innermost: Combine(Bold(), Color16(1)) is bold red.
+func Combine(styles ...Style) Style {
+	return func(text string) string {
+		for i := len(styles) - 1; i >= 0; i-- {
+			if styles[i] != nil {
+				text = styles[i](text)
+			}
+		}
+		return text
+	}
+}
+
+// Theme is the palette every horus formatter draws with. Values of an error
+// take the style of its Category if the theme has one, else of its Severity,
+// else Value.
+type Theme struct {
+	Key       Style              // field names: Op, Message, Details, Stack, ...
+	DetailKey Style              // detail keys
+	Value     Style              // values of errors without a category or severity style
+	Function  Style              // stack frame functions
+	Location  Style              // stack frame file:line and collapsed-frame notes
+	Panic     Style              // FormatPanic banners
+	Emphasis  Style              // OneLineErr
+	Warning   Style              // LogNotFound warnings
+	Severity  map[Severity]Style // value style per severity
+	Category  map[string]Style   // value style per category, case-insensitive
+}
+
+// DefaultTheme returns the classic horus palette: yellow keys, red values,
+// magenta functions and dimmed locations, with values colored by severity.
+func DefaultTheme() *Theme {
+	boldRed := ansi.Combine(ansi.Bold, ansi.Red).Paint
+	return &Theme{
+		Key:       ansi.Yellow.Paint,
+		DetailKey: ansi.White.Paint,
+		Value:     ansi.Red.Paint,
+		Function:  ansi.Magenta.Paint,
+		Location:  ansi.Dim.Paint,
+		Panic:     ansi.Red.Paint,
+		Emphasis:  boldRed,
+		Warning:   ansi.Yellow.Paint,
+		Severity: map[Severity]Style{
+			SeverityDebug:    ansi.Dim.Paint,
+			SeverityInfo:     ansi.Cyan.Paint,
+			SeverityWarning:  ansi.Yellow.Paint,
+			SeverityError:    ansi.Red.Paint,
+			SeverityCritical: boldRed,
+			SeverityFatal:    boldRed,
+		},
+	}
+}
+
+// MonochromeTheme returns a theme without any style.
+func MonochromeTheme() *Theme {
+	return &Theme{}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var theme atomic.Pointer[Theme]
+
+func init() {
+	theme.Store(DefaultTheme())
+}
+
+// SetTheme replaces the package-wide Theme and returns the previous one. A
+// nil Theme restores DefaultTheme.
+func SetTheme(t *Theme) *Theme {
+	if t == nil {
+		t = DefaultTheme()
+	}
+	return theme.Swap(t)
+}
+
+// GetTheme returns the package-wide Theme.
+func GetTheme() *Theme {
+	return theme.Load()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// paint applies style to text unless colors are turned off.
+func paint(style Style, text string) string {
+	if style == nil || !colorsAllowed() {
+		return text
+	}
+	return style(text)
+}
+
+// severityStyle returns the style for severity s, falling back to Value.
+func (t *Theme) severityStyle(s Severity) Style {
+	if style, ok := t.Severity[s]; ok && style != nil {
+		return style
+	}
+	return t.Value
+}
+
+// valueStyle returns the style for h's values: its category's, else its
+// severity's, else Value.
+func (t *Theme) valueStyle(h *Herror) Style {
+	if h.Category != "" {
+		for category, style := range t.Category {
+			if strings.EqualFold(category, h.Category) && style != nil {
+				return style
+			}
+		}
+	}
+	return t.severityStyle(h.Severity)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,183 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// clearColorEnv runs a test with neither NO_COLOR nor FORCE_COLOR set.
+func clearColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+}
+
+func TestColorEnabled(t *testing.T) {
+	clearColorEnv(t)
+	buf := &bytes.Buffer{}
+
+	if ColorEnabled(buf) {
+		t.Error("a buffer is not a terminal")
+	}
+	t.Setenv("FORCE_COLOR", "1")
+	if !ColorEnabled(buf) {
+		t.Error("FORCE_COLOR should enable colors")
+	}
+	t.Setenv("FORCE_COLOR", "0")
+	if ColorEnabled(buf) {
+		t.Error("FORCE_COLOR=0 should not enable colors")
+	}
+
+	defer SetColorMode(SetColorMode(ColorAlways))
+	if !ColorEnabled(buf) {
+		t.Error("ColorAlways should enable colors")
+	}
+	SetColorMode(ColorNever)
+	t.Setenv("FORCE_COLOR", "1")
+	if ColorEnabled(os.Stderr) {
+		t.Error("ColorNever should disable colors")
+	}
+}
+
+func TestColorEnabled_NoColor(t *testing.T) {
+	clearColorEnv(t)
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled(os.Stderr) || OneLineErr("x") != "x" {
+		t.Error("NO_COLOR should disable colors")
+	}
+	t.Setenv("FORCE_COLOR", "true")
+	if !ColorEnabled(&bytes.Buffer{}) {
+		t.Error("FORCE_COLOR should win over NO_COLOR")
+	}
+}
+
+func TestNewColorWriter(t *testing.T) {
+	clearColorEnv(t)
+	buf := &bytes.Buffer{}
+	n, err := NewColorWriter(buf).Write([]byte(OneLineErr("boom")))
+	if err != nil || n != len(OneLineErr("boom")) {
+		t.Errorf("Write = %d, %v", n, err)
+	}
+	if buf.String() != "boom" {
+		t.Errorf("colors should be stripped, got %q", buf.String())
+	}
+
+	defer SetColorMode(SetColorMode(ColorAlways))
+	if w := NewColorWriter(buf); w != buf {
+		t.Error("writer should be returned as is when colors are enabled")
+	}
+}
+
+func TestColorOutputSites(t *testing.T) {
+	clearColorEnv(t)
+	buf := &bytes.Buffer{}
+	CheckErr(errors.New("disk full"), WithWriter(buf), WithExitFunc(func(int) {}))
+	LogNotFound("ctx", WithLogWriter(buf))("addr")
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("non-terminal output should be plain:\n%q", buf.String())
+	}
+
+	defer SetColorMode(SetColorMode(ColorAlways))
+	buf.Reset()
+	LogNotFound("ctx", WithLogWriter(buf))("addr")
+	if !strings.HasPrefix(buf.String(), "\x1b[33mWarning:") {
+		t.Errorf("ColorAlways output should be colored: %q", buf.String())
+	}
+}
+
+func TestColorNever_Formatters(t *testing.T) {
+	defer SetColorMode(SetColorMode(ColorNever))
+	h, _ := AsHerror(NewSeverityHerror("op", "io", SeverityFatal, "msg", errors.New("eof"), map[string]any{"k": "v"}))
+	for name, out := range map[string]string{
+		"PseudoJSONFormatter":    PseudoJSONFormatter(h),
+		"SimpleColoredFormatter": SimpleColoredFormatter(h),
+		"template":               MustTemplateFormatter(`{{red .Op}} {{value .Herror .Op}}`)(h),
+		"FormatPanic":            FormatPanic("op", "msg"),
+	} {
+		if out != StripANSI(out) {
+			t.Errorf("%s should be plain under ColorNever: %q", name, out)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestDefaultTheme_Escapes(t *testing.T) {
+	clearColorEnv(t)
+	if got := OneLineErr("x"); got != "\x1b[1m\x1b[31mx\x1b[39m\x1b[22m" {
+		t.Errorf("OneLineErr = %q", got)
+	}
+	if got := FormatPanic("o", "m"); got != "\x1b[31mPanic [o]: m\x1b[39m" {
+		t.Errorf("FormatPanic = %q", got)
+	}
+}
+
+func TestTheme_CategoryAndSeverity(t *testing.T) {
+	clearColorEnv(t)
+	mark := func(tag string) Style {
+		return func(s string) string { return "<" + tag + ">" + s }
+	}
+	custom := DefaultTheme()
+	custom.Key = mark("key")
+	custom.Severity[SeverityWarning] = mark("warn")
+	custom.Category = map[string]Style{"Network": mark("net")}
+	defer SetTheme(SetTheme(custom))
+
+	netErr, _ := AsHerror(NewSeverityHerror("dial", "network", SeverityWarning, "refused", nil, nil))
+	if got := SimpleColoredFormatter(netErr); got != "<net>WARNING: "+netErr.Error() {
+		t.Errorf("category style should win: %q", got)
+	}
+	warn, _ := AsHerror(NewSeverityHerror("dial", "io", SeverityWarning, "slow", nil, nil))
+	if got := SimpleColoredFormatter(warn); !strings.HasPrefix(got, "<warn>WARNING: ") {
+		t.Errorf("severity style expected: %q", got)
+	}
+	if out := PseudoJSONFormatter(netErr); !strings.Contains(out, "<key>Op") || !strings.Contains(out, "<net>dial") {
+		t.Errorf("PseudoJSONFormatter ignores the theme:\n%s", out)
+	}
+
+	if prev := SetTheme(nil); prev != custom {
+		t.Error("SetTheme should return the previous theme")
+	}
+	if GetTheme().Key == nil {
+		t.Error("SetTheme(nil) should restore DefaultTheme")
+	}
+	SetTheme(MonochromeTheme())
+	if got := OneLineErr("x"); got != "x" {
+		t.Errorf("MonochromeTheme should not style: %q", got)
+	}
+}
+
+func TestStyleConstructors(t *testing.T) {
+	clearColorEnv(t)
+	custom := MonochromeTheme()
+	custom.Emphasis = Combine(Bold(), Underline(), TrueColor(1, 2, 3))
+	custom.Panic = Combine(Dim(), Color256(208))
+	custom.Warning = Color16(9)
+	defer SetTheme(SetTheme(custom))
+
+	if got := OneLineErr("x"); got != "\x1b[1m\x1b[4m\x1b[38;2;1;2;3mx\x1b[39m\x1b[24m\x1b[22m" {
+		t.Errorf("OneLineErr = %q", got)
+	}
+	if got := FormatPanic("o", "m"); got != "\x1b[2m\x1b[38;5;208mPanic [o]: m\x1b[39m\x1b[22m" {
+		t.Errorf("FormatPanic = %q", got)
+	}
+	if got := Color16(9)("x"); got != "\x1b[91mx\x1b[39m" {
+		t.Errorf("Color16(9) = %q", got)
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	if got := StripANSI("\x1b[1m\x1b[31mred\x1b[39m\x1b[22m \x1b[38;5;208mx\x1b[0m"); got != "red x" {
+		t.Errorf("StripANSI = %q", got)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,11 +5,8 @@ package horus
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"runtime"
 	"strings"
 )
 
@@ -22,37 +19,77 @@ type Herror struct {
 	Err      error          // The underlying error, if any
 	Details  map[string]any // Optional details for more specific context
 	Category string         // Error category (e.g., validation, IO, etc.)
+	Code     string         // Stable machine-readable code (e.g. "NOT_FOUND"), see Define
+	Severity Severity       // How serious the error is; SeverityUnset if not set
 	Stack    []uintptr      // Stack trace captured at the time of error creation.
+
+	frames []Frame   // frames decoded from JSON, used when Stack is empty
+	def    *Sentinel // definition the error was created from, if any
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// Error generates a human-readable representation of the error.
+// Error generates a human-readable representation of the error. Details are
+// shown through the package-wide Redactor.
 func (e *Herror) Error() string {
+	return e.describe(true)
+}
+
+// describe renders the error, including the text of the wrapped error only
+// when withCause is set.
+func (e *Herror) describe(withCause bool) string {
 	msg := fmt.Sprintf("operation '%s' failed", e.Op)
 	if e.Message != "" {
 		msg += fmt.Sprintf(": %s", e.Message)
 	}
-	if e.Err != nil {
+	if withCause && e.Err != nil {
 		msg += fmt.Sprintf(" (caused by: %v)", e.Err)
 	}
 	if len(e.Details) > 0 {
-		msg += fmt.Sprintf(" (details: %v)", e.Details)
+		msg += fmt.Sprintf(" (details: %v)", e.redactedDetails())
 	}
 	if e.Category != "" {
 		msg += fmt.Sprintf(" [category: %s]", e.Category)
 	}
+	if e.Code != "" {
+		msg += fmt.Sprintf(" [code: %s]", e.Code)
+	}
 	return msg
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // Format generates a custom representation of the error using a formatter function.
+// With %+v it appends the stack: the deepest stack of the chain is printed once,
+// and every outer layer shows only the frames that differ from the layer below.
+// When the chain branches (errors.Join, MultiError) %+v prints FormatTree
+// instead of the one-line message.
 func (e *Herror) Format(f fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if f.Flag('+') {
-			fmt.Fprintf(f, "%s\n%s", e.Error(), e.StackTrace())
+			if hasBranches(e) {
+				io.WriteString(f, FormatTree(e)+"\n")
+			} else {
+				io.WriteString(f, e.Error()+"\n")
+			}
+			layers := stackLayers(e)
+			if len(layers) == 1 {
+				// the only stack may belong to an inner layer (StackInnermost)
+				for _, frame := range layers[0].frames {
+					fmt.Fprintf(f, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+				}
+				return
+			}
+			for _, layer := range layers {
+				fmt.Fprintf(f, "--- %s\n", layer.herr.Op)
+				for _, frame := range layer.frames {
+					fmt.Fprintf(f, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+				}
+				if layer.shared > 0 {
+					fmt.Fprintf(f, "\t... %d frames in common with the layer below\n", layer.shared)
+				}
+			}
 			return
 		}
 	}
@@ -70,57 +107,32 @@ func (e *Herror) Unwrap() error {
 
 // StackTrace returns a formatted stack trace captured when the error was created.
 func (e *Herror) StackTrace() string {
-	if len(e.Stack) == 0 {
-		return ""
-	}
-	frames := runtime.CallersFrames(e.Stack)
 	var sb strings.Builder
-	for {
-		frame, more := frames.Next()
+	for _, frame := range e.Frames() {
 		sb.WriteString(fmt.Sprintf("%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line))
-		if !more {
-			break
-		}
 	}
 	return sb.String()
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func (h *Herror) HasStack() bool { return len(h.Stack) > 0 }
+func (h *Herror) HasStack() bool { return len(h.Stack) > 0 || len(h.frames) > 0 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// MarshalJSON ensures Err is emitted as its Error() string, not an object.
-func (h *Herror) MarshalJSON() ([]byte, error) {
-	type alias Herror
-	// if there’s no inner error, marshal it as empty string
-	errMsg := ""
-	if h.Err != nil {
-		errMsg = h.Err.Error()
-	}
-	return json.Marshal(&struct {
-		Err string `json:"Err"`
-		*alias
-	}{
-		Err:   errMsg,
-		alias: (*alias)(h),
-	})
-}
-
-////////////////////////////////////////////////////////////////////////////////////////////////////
-
-// captureStack captures the current call stack.
-func captureStack() []uintptr {
-	const depth = 32
-	var pcs [depth]uintptr
-	n := runtime.Callers(3, pcs[:])
-	return pcs[:n]
+func newHerror(
+	op, category, message string,
+	err error,
+	details map[string]any,
+) *Herror {
+	return buildHerror(GetStackConfig(), 1, op, category, message, err, details)
 }
 
-////////////////////////////////////////////////////////////////////////////////////////////////////
-
-func newHerror(
+// buildHerror creates an Herror whose stack is captured according to sc,
+// leaving out skip frames above buildHerror's caller.
+func buildHerror(
+	sc StackConfig,
+	skip int,
 	op, category, message string,
 	err error,
 	details map[string]any,
@@ -134,7 +146,7 @@ func newHerror(
 		Err:      err,
 		Details:  details,
 		Category: category,
-		Stack:    captureStack(),
+		Stack:    sc.capture(err, skip+1),
 	}
 }
 
@@ -172,14 +184,17 @@ func Wrap(err error, op, message string) error {
 			Err:      err,
 			Details:  herr.Details,
 			Category: herr.Category,
-			Stack:    captureStack(),
+			Code:     herr.Code,
+			Severity: herr.Severity,
+			Stack:    GetStackConfig().capture(err, 1),
+			def:      herr.def,
 		}
 	}
 	return &Herror{
 		Op:      op,
 		Message: message,
 		Err:     err,
-		Stack:   captureStack(),
+		Stack:   GetStackConfig().capture(err, 1),
 	}
 }
 
@@ -201,15 +216,3 @@ func WithDetail(err error, k string, v any) error {
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
-
-func RootCause(err error) error {
-	for {
-		next := errors.Unwrap(err)
-		if next == nil {
-			return err
-		}
-		err = next
-	}
-}
-
-////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,140 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Exit codes from sysexits.h.
+const (
+	ExitUsage       = 64 // command line usage error
+	ExitDataErr     = 65 // data format error
+	ExitNoInput     = 66 // cannot open input
+	ExitUnavailable = 69 // service unavailable
+	ExitSoftware    = 70 // internal software error
+	ExitIOErr       = 74 // input/output error
+	ExitConfig      = 78 // configuration error
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExitCodeTable maps error categories and root-cause errors to process exit
+// codes. Categories are matched case-insensitively. It is safe for concurrent use.
+type ExitCodeTable struct {
+	mu         sync.RWMutex
+	categories map[string]int
+	causes     []causeCode
+}
+
+type causeCode struct {
+	match func(error) bool
+	code  int
+}
+
+// NewExitCodeTable returns an empty table.
+func NewExitCodeTable() *ExitCodeTable {
+	return &ExitCodeTable{categories: make(map[string]int)}
+}
+
+// DefaultExitCodes returns a table with sysexits.h-style defaults:
+//
+//	usage                          → 64
+//	dataerr, data, validation      → 65
+//	noinput, not_found             → 66
+//	unavailable                    → 69
+//	software, internal             → 70
+//	ioerr, io, io_error            → 74
+//	config, config_error           → 78
+//
+// plus root causes fs.ErrNotExist → 66, context.DeadlineExceeded → 69 and
+// *os.PathError → 74.
+func DefaultExitCodes() *ExitCodeTable {
+	t := NewExitCodeTable()
+	for code, cats := range map[int][]string{
+		ExitUsage:       {"usage"},
+		ExitDataErr:     {"dataerr", "data", "validation"},
+		ExitNoInput:     {"noinput", "not_found"},
+		ExitUnavailable: {"unavailable"},
+		ExitSoftware:    {"software", "internal"},
+		ExitIOErr:       {"ioerr", "io", "io_error"},
+		ExitConfig:      {"config", "config_error"},
+	} {
+		for _, cat := range cats {
+			t.SetCategory(cat, code)
+		}
+	}
+	t.SetCause(fs.ErrNotExist, ExitNoInput)
+	t.SetCause(context.DeadlineExceeded, ExitUnavailable)
+	ExitCodeForType[*os.PathError](t, ExitIOErr)
+	return t
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SetCategory maps category to code and returns the table for chaining.
+func (t *ExitCodeTable) SetCategory(category string, code int) *ExitCodeTable {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.categories[strings.ToLower(category)] = code
+	return t
+}
+
+// SetCause maps any error matching target (via errors.Is) to code.
+// Causes are tried in the order they were added.
+func (t *ExitCodeTable) SetCause(target error, code int) *ExitCodeTable {
+	return t.SetCauseFunc(func(err error) bool { return errors.Is(err, target) }, code)
+}
+
+// SetCauseFunc maps any error for which match returns true to code.
+func (t *ExitCodeTable) SetCauseFunc(match func(error) bool, code int) *ExitCodeTable {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.causes = append(t.causes, causeCode{match: match, code: code})
+	return t
+}
+
+// ExitCodeForType maps any error in the chain of type T (via errors.As) to code.
+func ExitCodeForType[T error](t *ExitCodeTable, code int) *ExitCodeTable {
+	return t.SetCauseFunc(func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}, code)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Lookup returns the exit code for err. Categories of every Herror in the
+// chain are tried first, outermost layer first; then the cause matchers.
+func (t *ExitCodeTable) Lookup(err error) (int, bool) {
+	if t == nil || err == nil {
+		return 0, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if herr, ok := e.(*Herror); ok && herr.Category != "" {
+			if code, ok := t.categories[strings.ToLower(herr.Category)]; ok {
+				return code, true
+			}
+		}
+	}
+	for _, c := range t.causes {
+		if c.match(err) {
+			return c.code, true
+		}
+	}
+	return 0, false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
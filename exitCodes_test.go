@@ -0,0 +1,98 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestDefaultExitCodes_Lookup(t *testing.T) {
+	table := DefaultExitCodes()
+
+	pathErr := &os.PathError{Op: "read", Path: "/x", Err: errors.New("bad sector")}
+	missing := &os.PathError{Op: "open", Path: "/x", Err: os.ErrNotExist}
+
+	tests := []struct {
+		name string
+		err  error
+		code int
+		ok   bool
+	}{
+		{"plain", errors.New("x"), 0, false},
+		{"usage", NewCategorizedHerror("op", "usage", "", nil, nil), ExitUsage, true},
+		{"case insensitive", NewCategorizedHerror("op", "IO_ERROR", "", nil, nil), ExitIOErr, true},
+		{"config", NewCategorizedHerror("op", "CONFIG_ERROR", "", nil, nil), ExitConfig, true},
+		{"inner category", Wrap(NewCategorizedHerror("op", "validation", "", nil, nil), "outer", ""), ExitDataErr, true},
+		{"outer wins", PropagateErr("outer", "config", "", NewCategorizedHerror("op", "io", "", nil, nil), nil), ExitConfig, true},
+		{"unknown category falls through", NewCategorizedHerror("op", "runtime_error", "", missing, nil), ExitNoInput, true},
+		{"path error", fmt.Errorf("wrapped: %w", pathErr), ExitIOErr, true},
+		{"deadline", Wrap(context.DeadlineExceeded, "fetch", ""), ExitUnavailable, true},
+	}
+
+	for _, tc := range tests {
+		code, ok := table.Lookup(tc.err)
+		if code != tc.code || ok != tc.ok {
+			t.Errorf("%s: Lookup = %d, %v; want %d, %v", tc.name, code, ok, tc.code, tc.ok)
+		}
+	}
+}
+
+func TestExitCodeTable_Custom(t *testing.T) {
+	errQuota := errors.New("quota exceeded")
+	table := NewExitCodeTable().
+		SetCategory("billing", 90).
+		SetCause(errQuota, 91)
+
+	if code, _ := table.Lookup(NewCategorizedHerror("op", "Billing", "", nil, nil)); code != 90 {
+		t.Errorf("billing code = %d; want 90", code)
+	}
+	if code, _ := table.Lookup(Wrap(errQuota, "op", "")); code != 91 {
+		t.Errorf("quota code = %d; want 91", code)
+	}
+
+	var nilTable *ExitCodeTable
+	if _, ok := nilTable.Lookup(errQuota); ok {
+		t.Error("nil table should never match")
+	}
+}
+
+func TestCheckErr_ExitCodeResolution(t *testing.T) {
+	var code int
+	r := NewReporter(WithWriter(&bytes.Buffer{}), WithExitFunc(func(c int) { code = c }))
+
+	r.CheckErr(errors.New("x"))
+	if code != 1 {
+		t.Errorf("uncategorized exit code = %d; want 1", code)
+	}
+
+	r.CheckErr(NewCategorizedHerror("op", "config", "", nil, nil))
+	if code != ExitConfig {
+		t.Errorf("inner config exit code = %d; want %d", code, ExitConfig)
+	}
+
+	r.CheckErr(errors.New("x"), WithCategory("usage"))
+	if code != ExitUsage {
+		t.Errorf("WithCategory(usage) exit code = %d; want %d", code, ExitUsage)
+	}
+
+	r.CheckErr(NewCategorizedHerror("op", "config", "", nil, nil), WithExitCode(5))
+	if code != 5 {
+		t.Errorf("explicit exit code = %d; want 5", code)
+	}
+
+	r.CheckErr(NewCategorizedHerror("op", "config", "", nil, nil), WithExitCodes(nil))
+	if code != 1 {
+		t.Errorf("nil table exit code = %d; want 1", code)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
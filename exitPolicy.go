@@ -0,0 +1,43 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExitPolicy decides what happens once CheckErr or Report has printed an
+// error. It receives the reported *Herror and the exit code; whatever it
+// returns is handed back to the caller of Report.
+type ExitPolicy func(h *Herror, code int) error
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExitProcess returns an ExitPolicy that terminates through exit (usually
+// os.Exit). If exit returns, as test doubles do, the policy returns the error.
+func ExitProcess(exit func(int)) ExitPolicy {
+	return func(h *Herror, code int) error {
+		exit(code)
+		return h
+	}
+}
+
+// PanicPolicy panics with the reported *Herror, so deferred Recover/RecoverTo
+// calls further up the stack can turn it back into an error.
+func PanicPolicy(h *Herror, code int) error {
+	panic(h)
+}
+
+// ReturnPolicy returns the reported *Herror without terminating.
+func ReturnPolicy(h *Herror, code int) error {
+	return h
+}
+
+// CallbackPolicy returns an ExitPolicy that calls fn with the reported error
+// and exit code, then returns the error.
+func CallbackPolicy(fn func(h *Herror, code int)) ExitPolicy {
+	return func(h *Herror, code int) error {
+		fn(h, code)
+		return h
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,120 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestReport_ReturnsWithoutExiting(t *testing.T) {
+	buf := &bytes.Buffer{}
+	exited := false
+	r := NewReporter(WithWriter(buf), WithExitFunc(func(int) { exited = true }))
+
+	if err := r.Report(nil); err != nil {
+		t.Errorf("Report(nil) = %v; want nil", err)
+	}
+
+	inner := errors.New("disk full")
+	err := r.Report(inner, WithOp("save"))
+	if exited {
+		t.Error("Report must not apply the reporter's exit function")
+	}
+	if !errors.Is(err, inner) {
+		t.Errorf("Report returned %v; want it to wrap %v", err, inner)
+	}
+	if op, _ := Operation(err); op != "save" {
+		t.Errorf("Operation = %q; want %q", op, "save")
+	}
+	if out := stripANSI(buf.String()); !strings.Contains(out, "disk full") {
+		t.Errorf("Report did not print the error: %q", out)
+	}
+	if got := r.ErrorRegistry()["unknown"]; got != 1 {
+		t.Errorf("Report did not register the error (count %d)", got)
+	}
+}
+
+func TestWarn_NeverExits(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := NewReporter(WithWriter(buf), WithExitPolicy(PanicPolicy))
+
+	r.Warn(errors.New("slow disk"))
+
+	out := stripANSI(buf.String())
+	for _, want := range []string{"slow disk", "warning"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Warn output missing %q: %q", want, out)
+		}
+	}
+}
+
+func TestWarn_KeepsReporterDefaults(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := NewReporter(WithWriter(buf), WithFormatter(LogfmtFormatter),
+		WithMessage("billing worker failed"), WithDetails(map[string]any{"service": "billing"}))
+
+	r.Warn(errors.New("slow disk"))
+	out := buf.String()
+	if !strings.Contains(out, `message="billing worker failed"`) || !strings.Contains(out, "details.service=billing") ||
+		strings.Contains(out, "details.location") || !strings.Contains(out, "severity=warning") {
+		t.Errorf("Warn should keep the Reporter's message and details: %q", out)
+	}
+
+	buf.Reset()
+	NewReporter(WithWriter(buf), WithFormatter(LogfmtFormatter)).Warn(errors.New("slow disk"))
+	if out := buf.String(); !strings.Contains(out, `message="A warning was raised during execution"`) || !strings.Contains(out, "details.location=warn") {
+		t.Errorf("Warn defaults missing: %q", out)
+	}
+}
+
+func TestExitPolicies(t *testing.T) {
+	t.Run("callback", func(t *testing.T) {
+		var gotCode int
+		var gotErr *Herror
+		r := NewReporter(
+			WithWriter(&bytes.Buffer{}),
+			WithExitCode(3),
+			WithExitPolicy(CallbackPolicy(func(h *Herror, code int) { gotErr, gotCode = h, code })),
+		)
+		r.CheckErr(errors.New("x"))
+		if gotCode != 3 || gotErr == nil {
+			t.Errorf("callback got (%v, %d); want (herror, 3)", gotErr, gotCode)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		r := NewReporter(WithWriter(&bytes.Buffer{}), WithExitPolicy(PanicPolicy))
+		work := func() (err error) {
+			defer RecoverTo(&err)
+			r.CheckErr(errors.New("fatal"), WithOp("job"))
+			return nil
+		}
+		err := work()
+		if op, _ := Operation(err); op != "job" {
+			t.Errorf("recovered %v; want the reported Herror", err)
+		}
+	})
+
+	t.Run("return", func(t *testing.T) {
+		r := NewReporter(WithWriter(&bytes.Buffer{}), WithExitPolicy(ReturnPolicy))
+		r.CheckErr(errors.New("ignored")) // must simply return
+	})
+
+	t.Run("report override", func(t *testing.T) {
+		code := 0
+		r := NewReporter(WithWriter(&bytes.Buffer{}))
+		r.Report(errors.New("x"), WithExitFunc(func(c int) { code = c }), WithExitCode(9))
+		if code != 9 {
+			t.Errorf("Report with explicit policy exit code = %d; want 9", code)
+		}
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
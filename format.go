@@ -7,11 +7,7 @@ package horus
 import (
 	"encoding/json"
 	"fmt"
-	"runtime"
-	"sort"
 	"strings"
-
-	"github.com/ttacon/chalk"
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -38,110 +34,31 @@ func JSONFormatter(h *Herror) string {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func PseudoJSONFormatter(h *Herror) string {
-	var b strings.Builder
-
-	type field struct {
-		key   string
-		value string
-		color chalk.Color
-	}
-
-	// Collect top-level fields (excluding Stack)
-	fields := []field{
-		{"Op", h.Op, chalk.Yellow},
-		{"Message", h.Message, chalk.Yellow},
-		{"Err", fmt.Sprintf("%v", h.Err), chalk.Yellow},
-	}
-	// only append Category if non-empty
-	if h.Category != "" {
-		fields = append(fields, field{"Category", h.Category, chalk.Yellow})
-	}
-
-	// Convert Details into aligned field list
-	// before building detailFields:
-	keys := make([]string, 0, len(h.Details))
-	var detailFields []field
-	for k := range h.Details {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	for _, k := range keys {
-		detailFields = append(detailFields, field{
-			key:   k,
-			value: fmt.Sprintf("%v", h.Details[k]),
-			color: chalk.White,
-		})
-	}
-
-	// Compute max key width for padding
-	maxLen := 0
-	for _, f := range append(fields, detailFields...) {
-		if len(f.key) > maxLen {
-			maxLen = len(f.key)
-		}
-	}
-
-	// Render top-level fields (except Stack)
-	for _, f := range fields[:3] {
-		padded := fmt.Sprintf("%-*s", maxLen, f.key)
-		fmt.Fprintf(&b, "%s %s,\n", f.color.Color(padded), chalk.Red.Color(f.value))
-	}
-
-	// Render Details
-	b.WriteString(chalk.Yellow.Color("Details") + "\n")
-	for _, f := range detailFields {
-		padded := fmt.Sprintf("  %-*s", maxLen, f.key)
-		fmt.Fprintf(&b, "%s %s,\n", f.color.Color(padded), chalk.Red.Color(f.value))
-	}
-	b.WriteString("\n")
-
-	// Render Category
-	padded := fmt.Sprintf("%-*s", maxLen, fields[3].key)
-	fmt.Fprintf(&b, "%s %s,\n", fields[3].color.Color(padded), chalk.Red.Color(fields[3].value))
-
-	// Render Stack (show function in magenta, location dimmed)
-	b.WriteString(chalk.Yellow.Color("Stack") + "\n")
-
-	frames := runtime.CallersFrames(h.Stack)
-	for {
-		frame, more := frames.Next()
-
-		// colorize parts separately
-		fn := chalk.Magenta.Color(frame.Function + "()")
-		loc := chalk.Dim.TextStyle(fmt.Sprintf(" %s:%d", frame.File, frame.Line))
-
-		b.WriteString("  " + fn + loc + "\n")
-
-		if !more {
-			break
-		}
-	}
-
-	return b.String()
-}
-
-////////////////////////////////////////////////////////////////////////////////////////////////////
-
-// SimpleColoredFormatter generates a colored representation of an Herror using the chalk library.
-// You can extend this function to use different colors based on the error category.
+// SimpleColoredFormatter generates a colored one-line representation of an Herror.
+// The line is labeled by Severity ("ERROR:" without one) and styled like
+// PseudoJSONFormatter values: by Category, then Severity.
 func SimpleColoredFormatter(h *Herror) string {
-	return chalk.Red.Color(fmt.Sprintf("ERROR: %s", h.Error()))
+	label := "ERROR"
+	if h.Severity != SeverityUnset {
+		label = strings.ToUpper(h.Severity.String())
+	}
+	return paint(GetTheme().valueStyle(h), fmt.Sprintf("%s: %s", label, h.Error()))
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// FormatPanic returns a red-formatted panic message for the given operation and message.
+// FormatPanic returns a panic message for the given operation and message,
+// styled with the Theme's Panic style (red by default).
 func FormatPanic(op, message string) string {
-	return chalk.Red.Color(fmt.Sprintf("Panic [%s]: %s", op, message))
+	return paint(GetTheme().Panic, fmt.Sprintf("Panic [%s]: %s", op, message))
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// OneLineErr returns a bold, red version of the input string.
+// OneLineErr returns the input string in the Theme's Emphasis style (bold red by default).
 // Useful for displaying a single‑line error message without the full Herror structure.
 func OneLineErr(er string) string {
-	return chalk.Bold.TextStyle(chalk.Red.Color(er))
+	return paint(GetTheme().Emphasis, er)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
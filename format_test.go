@@ -15,12 +15,12 @@ import (
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // stripANSI removes ANSI color escapes so we can assert on plain text.
-var ansi = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+var ansiColor = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func stripANSI(s string) string {
-	return ansi.ReplaceAllString(s, "")
+	return ansiColor.ReplaceAllString(s, "")
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -134,7 +134,6 @@ func TestPseudoJSONFormatter_Content(t *testing.T) {
 		{"Details", ""},   // just the header
 		{"key1", "val1,"}, // nested detail
 		{"Category", "myCat,"},
-		{"Stack", ""}, // just the header
 	}
 
 	for _, tc := range tests {
@@ -160,6 +159,9 @@ func TestPseudoJSONFormatter_Content(t *testing.T) {
 			)
 		}
 	}
+	if strings.Contains(out, "Stack") {
+		t.Errorf("an Herror without frames should have no Stack section:\n%s", out)
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
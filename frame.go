@@ -0,0 +1,133 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Frame is a single symbolized stack frame.
+type Frame struct {
+	Function string  // fully qualified function, e.g. "github.com/x/y.(*T).Method"
+	Package  string  // import path of the function's package, e.g. "github.com/x/y"
+	File     string  // absolute path of the source file
+	Line     int     // line number in File
+	PC       uintptr // program counter, meaningful only inside the creating process
+}
+
+// Name returns the function name without its package path, e.g. "(*T).Method".
+func (f Frame) Name() string {
+	slash := strings.LastIndex(f.Function, "/")
+	if dot := strings.Index(f.Function[slash+1:], "."); dot >= 0 {
+		return f.Function[slash+1+dot+1:]
+	}
+	return f.Function
+}
+
+// String returns "function file:line".
+func (f Frame) String() string {
+	return fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Frames symbolizes the stack captured when the error was created and applies
+// the package-wide frame filters (see SetFrameFilters). It returns nil when no
+// stack was captured.
+func (h *Herror) Frames() []Frame {
+	return applyFrameFilters(h.RawFrames())
+}
+
+// RawFrames symbolizes the captured stack without applying any frame filter.
+// For an Herror decoded from JSON it returns the decoded frames.
+func (h *Herror) RawFrames() []Frame {
+	if len(h.Stack) == 0 {
+		if len(h.frames) == 0 {
+			return nil
+		}
+		return append([]Frame(nil), h.frames...)
+	}
+	out := make([]Frame, 0, len(h.Stack))
+	frames := runtime.CallersFrames(h.Stack)
+	for {
+		frame, more := frames.Next()
+		out = append(out, Frame{
+			Function: frame.Function,
+			Package:  funcPackage(frame.Function),
+			File:     frame.File,
+			Line:     frame.Line,
+			PC:       frame.PC,
+		})
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// stackLayer is one Herror of a chain together with the frames it adds on top
+// of the next deeper Herror that carries a stack.
+type stackLayer struct {
+	herr   *Herror
+	frames []Frame // frames unique to this layer; every frame for the deepest
+	shared int     // trailing frames identical to the deeper layer, omitted
+}
+
+// stackLayers walks err's chain and returns every Herror that carries a
+// stack, outermost first. The deepest layer keeps its full stack; each outer
+// layer keeps only the frames that differ from the layer below it, the way
+// pkg/errors-style tooling collapses shared suffixes.
+func stackLayers(err error) []stackLayer {
+	var layers []stackLayer
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if h, ok := e.(*Herror); ok {
+			if frames := h.Frames(); len(frames) > 0 {
+				layers = append(layers, stackLayer{herr: h, frames: frames})
+			}
+		}
+	}
+	for i := 0; i < len(layers)-1; i++ {
+		n := sharedSuffix(layers[i].frames, layers[i+1].herr.Frames())
+		layers[i].frames = layers[i].frames[:len(layers[i].frames)-n]
+		layers[i].shared = n
+	}
+	return layers
+}
+
+// sharedSuffix counts the trailing frames a and b have in common.
+func sharedSuffix(a, b []Frame) int {
+	n := 0
+	for n < len(a) && n < len(b) {
+		fa, fb := a[len(a)-1-n], b[len(b)-1-n]
+		if fa.Function != fb.Function || fa.File != fb.File || fa.Line != fb.Line {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// funcPackage extracts the package import path from a fully qualified
+// function name: the path up to the first dot after the last slash. The
+// runtime escapes dots in the last path element as %2e; they are restored.
+func funcPackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	return strings.ReplaceAll(function[:slash+1+dot], "%2e", ".")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,146 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// FrameFilter rewrites or drops a stack frame. Returning false drops it.
+// Filters installed with SetFrameFilters are applied, in order, by Frames and
+// therefore by StackTrace, every formatter and MarshalJSON.
+type FrameFilter func(Frame) (Frame, bool)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var frameFilters atomic.Pointer[[]FrameFilter]
+
+func init() {
+	frameFilters.Store(&[]FrameFilter{})
+}
+
+// SetFrameFilters replaces the package-wide frame filters and returns the
+// previous ones. Call it with no arguments to show every frame again.
+func SetFrameFilters(filters ...FrameFilter) []FrameFilter {
+	return *frameFilters.Swap(&filters)
+}
+
+// GetFrameFilters returns the package-wide frame filters.
+func GetFrameFilters() []FrameFilter {
+	return *frameFilters.Load()
+}
+
+// applyFrameFilters runs every installed filter over frames.
+func applyFrameFilters(frames []Frame) []Frame {
+	filters := GetFrameFilters()
+	if len(filters) == 0 {
+		return frames
+	}
+	out := frames[:0]
+next:
+	for _, f := range frames {
+		for _, filter := range filters {
+			var keep bool
+			if f, keep = filter(f); !keep {
+				continue next
+			}
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DropPackages drops frames whose package is one of prefixes or nested
+// below one of them ("net" matches "net" and "net/http", not "netip").
+func DropPackages(prefixes ...string) FrameFilter {
+	return func(f Frame) (Frame, bool) {
+		return f, !matchPackage(f.Package, prefixes)
+	}
+}
+
+// KeepPackages keeps only frames whose package is one of prefixes or nested
+// below one of them, e.g. KeepPackages("github.com/me/app").
+func KeepPackages(prefixes ...string) FrameFilter {
+	return func(f Frame) (Frame, bool) {
+		return f, matchPackage(f.Package, prefixes)
+	}
+}
+
+// DropRuntime drops frames from the runtime and testing packages.
+func DropRuntime() FrameFilter {
+	return DropPackages("runtime", "testing")
+}
+
+// DropHorus drops frames from the horus package itself (constructors,
+// reporters, formatters). Frames from horus test files are kept.
+func DropHorus() FrameFilter {
+	return func(f Frame) (Frame, bool) {
+		return f, f.Package != horusPackage || strings.HasSuffix(f.File, "_test.go")
+	}
+}
+
+// TrimPaths shortens file paths by removing the first matching prefix. With no
+// prefixes it trims GOROOT/src, the module cache and GOPATH/src, turning
+// "/usr/local/go/src/net/http/server.go" into "net/http/server.go".
+func TrimPaths(prefixes ...string) FrameFilter {
+	if len(prefixes) == 0 {
+		prefixes = defaultTrimPrefixes()
+	}
+	return func(f Frame) (Frame, bool) {
+		for _, p := range prefixes {
+			if p != "" && strings.HasPrefix(f.File, p) {
+				f.File = strings.TrimLeft(strings.TrimPrefix(f.File, p), "/")
+				break
+			}
+		}
+		return f, true
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// horusPackage is the import path of this package, as it appears in frames.
+var horusPackage = funcPackage(runtime.FuncForPC(reflect.ValueOf(NewHerror).Pointer()).Name())
+
+func matchPackage(pkg string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if pkg == p || strings.HasPrefix(pkg, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultTrimPrefixes returns GOROOT/src, GOMODCACHE and GOPATH/src, each
+// ending in a separator.
+func defaultTrimPrefixes() []string {
+	modCache := os.Getenv("GOMODCACHE")
+	var prefixes []string
+	if build.Default.GOROOT != "" {
+		prefixes = append(prefixes, filepath.ToSlash(filepath.Join(build.Default.GOROOT, "src"))+"/")
+	}
+	for _, gopath := range filepath.SplitList(build.Default.GOPATH) {
+		if modCache == "" {
+			modCache = filepath.Join(gopath, "pkg", "mod")
+		}
+		prefixes = append(prefixes, filepath.ToSlash(filepath.Join(gopath, "src"))+"/")
+	}
+	if modCache != "" {
+		prefixes = append([]string{filepath.ToSlash(modCache) + "/"}, prefixes...)
+	}
+	return prefixes
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,83 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestFrameFilters_Builtins(t *testing.T) {
+	frames := []Frame{
+		{Function: "runtime.goexit", Package: "runtime", File: "/go/src/runtime/asm_amd64.s"},
+		{Function: "testing.tRunner", Package: "testing", File: "/go/src/testing/testing.go"},
+		{Function: horusPackage + ".NewHerror", Package: horusPackage, File: "/src/horus/error.go"},
+		{Function: horusPackage + ".TestX", Package: horusPackage, File: "/src/horus/x_test.go"},
+		{Function: "github.com/me/app.run", Package: "github.com/me/app", File: "/mod/github.com/me/app@v1/run.go"},
+		{Function: "github.com/me/appx.run", Package: "github.com/me/appx", File: "/elsewhere/appx.go"},
+	}
+
+	apply := func(f FrameFilter) []string {
+		var out []string
+		for _, fr := range frames {
+			if fr, ok := f(fr); ok {
+				out = append(out, fr.Function+"@"+fr.File)
+			}
+		}
+		return out
+	}
+
+	if got := apply(DropRuntime()); len(got) != 4 || strings.Contains(strings.Join(got, " "), "runtime.") {
+		t.Errorf("DropRuntime kept %v", got)
+	}
+	if got := apply(DropHorus()); len(got) != 5 || strings.Contains(strings.Join(got, " "), "NewHerror") {
+		t.Errorf("DropHorus kept %v", got)
+	}
+	if got := apply(KeepPackages("github.com/me/app")); len(got) != 1 || !strings.HasPrefix(got[0], "github.com/me/app.run") {
+		t.Errorf("KeepPackages kept %v", got)
+	}
+	got := apply(TrimPaths("/mod/", "/go/src/"))
+	if got[0] != "runtime.goexit@runtime/asm_amd64.s" || got[4] != "github.com/me/app.run@github.com/me/app@v1/run.go" ||
+		got[5] != "github.com/me/appx.run@/elsewhere/appx.go" {
+		t.Errorf("TrimPaths = %v", got)
+	}
+}
+
+func TestFrameFilters_AppliedUniformly(t *testing.T) {
+	defer SetFrameFilters(SetFrameFilters(DropRuntime(), DropHorus(), TrimPaths())...)
+
+	h, _ := AsHerror(NewCategorizedHerror("op", "cat", "", nil, nil))
+
+	raw := h.RawFrames()
+	frames := h.Frames()
+	if len(frames) == 0 || len(frames) >= len(raw) {
+		t.Fatalf("filters did not shrink the stack: %d raw, %d filtered", len(raw), len(frames))
+	}
+	if frames[0].Name() != "TestFrameFilters_AppliedUniformly" {
+		t.Errorf("first filtered frame = %q; want the test function", frames[0].Function)
+	}
+
+	for name, out := range map[string]string{
+		"StackTrace":          h.StackTrace(),
+		"PseudoJSONFormatter": stripANSI(PseudoJSONFormatter(h)),
+	} {
+		if strings.Contains(out, "NewCategorizedHerror") || strings.Contains(out, "runtime.goexit") {
+			t.Errorf("%s shows filtered frames:\n%s", name, out)
+		}
+	}
+
+	var m struct{ Frames []Frame }
+	if err := json.Unmarshal([]byte(JSONFormatter(h)), &m); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(m.Frames) != len(frames) {
+		t.Errorf("JSONFormatter frames = %d; want %d", len(m.Frames), len(frames))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,130 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestHerror_Frames(t *testing.T) {
+	if frames := (&Herror{}).Frames(); frames != nil {
+		t.Errorf("Frames() without stack = %v; want nil", frames)
+	}
+
+	h, _ := AsHerror(NewHerror("op", "", nil, nil))
+	frames := h.Frames()
+	if len(frames) < 2 {
+		t.Fatalf("Frames() = %v; want at least 2 frames", frames)
+	}
+
+	ctor, caller := frames[0], frames[1]
+	if ctor.Function != "github.com/DanielRivasMD/horus.NewHerror" {
+		t.Errorf("first frame = %q; want NewHerror", ctor.Function)
+	}
+	if caller.Package != "github.com/DanielRivasMD/horus" || caller.Name() != "TestHerror_Frames" {
+		t.Errorf("caller frame package/name = %q/%q", caller.Package, caller.Name())
+	}
+	if !strings.HasSuffix(caller.File, "frame_test.go") || caller.Line == 0 || caller.PC == 0 {
+		t.Errorf("caller frame location incomplete: %+v", caller)
+	}
+	if got := caller.String(); !strings.Contains(got, "frame_test.go:") {
+		t.Errorf("Frame.String() = %q", got)
+	}
+
+	// StackTrace is rendered from the same frames
+	if !strings.HasPrefix(h.StackTrace(), ctor.Function+"\n\t"+ctor.File) {
+		t.Errorf("StackTrace does not match Frames:\n%s", h.StackTrace())
+	}
+
+	if got := (Frame{Function: "gopkg.in/yaml%2ev3.(*parser).run"}).Name(); got != "(*parser).run" {
+		t.Errorf("Name() = %q; want %q", got, "(*parser).run")
+	}
+
+	if got, ok := Frames(errors.New("plain")); ok || got != nil {
+		t.Errorf("Frames(plain) = %v, %v; want nil, false", got, ok)
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	tests := map[string]string{
+		"github.com/x/y.(*T).Method":       "github.com/x/y",
+		"github.com/x/y.Func.func1":        "github.com/x/y",
+		"github.com/x/y.v2/z.Func":         "github.com/x/y.v2/z",
+		"main.main":                        "main",
+		"runtime.goexit":                   "runtime",
+		"noPackage":                        "",
+		"gopkg.in/yaml%2ev3.(*parser).run": "gopkg.in/yaml.v3",
+	}
+	for fn, want := range tests {
+		if got := funcPackage(fn); got != want {
+			t.Errorf("funcPackage(%q) = %q; want %q", fn, got, want)
+		}
+	}
+}
+
+func TestHerror_MarshalJSONFrames(t *testing.T) {
+	h, _ := AsHerror(NewHerror("op", "", nil, nil))
+	raw, err := json.Marshal(h)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	var m struct{ Frames []Frame }
+	if err := json.Unmarshal(raw, &m); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(m.Frames) != len(h.Frames()) || m.Frames[0].Function != h.Frames()[0].Function {
+		t.Errorf("JSON Frames = %v; want %v", m.Frames, h.Frames())
+	}
+}
+
+func deepestLayer() error { return NewHerror("open", "", errors.New("enoent"), nil) }
+
+func middleLayer() error { return Wrap(deepestLayer(), "read file", "") }
+
+func TestStackLayers_Dedup(t *testing.T) {
+	err := PropagateErr("load config", "io", "", middleLayer(), nil)
+
+	layers := stackLayers(err)
+	if len(layers) != 3 {
+		t.Fatalf("stackLayers = %d layers; want 3", len(layers))
+	}
+	deepest := layers[2]
+	if deepest.herr.Op != "open" || deepest.shared != 0 || len(deepest.frames) != len(deepest.herr.Frames()) {
+		t.Errorf("deepest layer should keep its full stack: %+v", deepest)
+	}
+	for _, layer := range layers[:2] {
+		if layer.shared == 0 {
+			t.Errorf("layer %q shares no frames with the layer below", layer.herr.Op)
+		}
+		if len(layer.frames)+layer.shared != len(layer.herr.Frames()) {
+			t.Errorf("layer %q: %d unique + %d shared != %d frames",
+				layer.herr.Op, len(layer.frames), layer.shared, len(layer.herr.Frames()))
+		}
+	}
+
+	out := fmt.Sprintf("%+v", err)
+	if got := strings.Count(out, "TestStackLayers_Dedup\n"); got != 1 {
+		t.Errorf("%%+v prints the shared caller frame %d times; want once:\n%s", got, out)
+	}
+	for _, want := range []string{"--- load config\n", "--- read file\n", "--- open\n", "frames in common with the layer below"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%%+v missing %q:\n%s", want, out)
+		}
+	}
+
+	h, _ := AsHerror(err)
+	pretty := stripANSI(PseudoJSONFormatter(h))
+	if got := strings.Count(pretty, "TestStackLayers_Dedup()"); got != 1 {
+		t.Errorf("PseudoJSONFormatter prints the shared caller frame %d times; want once:\n%s", got, pretty)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
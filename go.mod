@@ -1,5 +1,3 @@
 module github.com/DanielRivasMD/horus
 
 go 1.25
-
-require github.com/ttacon/chalk v0.0.0-20160626202418-22c06c80ed31
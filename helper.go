@@ -97,6 +97,26 @@ func Category(err error) (string, bool) {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// Code returns the code associated with an Herror, if present.
+func Code(err error) (string, bool) {
+	if herr, ok := AsHerror(err); ok {
+		return herr.Code, true
+	}
+	return "", false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HelpURL returns the documentation link of the error's definition, if any.
+func HelpURL(err error) (string, bool) {
+	if herr, ok := AsHerror(err); ok && herr.HelpURL() != "" {
+		return herr.HelpURL(), true
+	}
+	return "", false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // StackTrace returns the formatted stack trace from an error if it's an Herror.
 func StackTrace(err error) (string, bool) {
 	if herr, ok := AsHerror(err); ok {
@@ -107,14 +127,12 @@ func StackTrace(err error) (string, bool) {
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func RootCauseHelper(err error) error {
-	for {
-		if un := errors.Unwrap(err); un != nil {
-			err = un
-			continue
-		}
-		return err
+// Frames returns the symbolized stack frames from an error if it's an Herror.
+func Frames(err error) ([]Frame, bool) {
+	if herr, ok := AsHerror(err); ok {
+		return herr.Frames(), true
 	}
+	return nil, false
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
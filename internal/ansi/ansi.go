@@ -0,0 +1,111 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Package ansi styles terminal text with ANSI SGR escape sequences: the 16
+// basic colors, the 256-color palette, 24-bit truecolor, bold, dim and
+// underline. Each style closes with the sequence resetting only what it set,
+// so styles nest: Bold.Paint(Red.Paint(s)) keeps s bold after the color ends.
+package ansi
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"regexp"
+	"strconv"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Style is a pair of escape sequences wrapped around text. The zero Style
+// leaves text unchanged.
+type Style struct {
+	open  string
+	close string
+}
+
+// Paint wraps text in the style's escape sequences.
+func (s Style) Paint(text string) string {
+	if s.open == "" {
+		return text
+	}
+	return s.open + text + s.close
+}
+
+// Combine returns a style applying styles from the outermost to the innermost:
+// Combine(Bold, Red).Paint(s) equals Bold.Paint(Red.Paint(s)).
+func Combine(styles ...Style) Style {
+	var c Style
+	for _, s := range styles {
+		c.open += s.open
+		c.close = s.close + c.close
+	}
+	return c
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// sgr builds the escape sequence selecting the given SGR parameters.
+func sgr(params string) string {
+	return "\x1b[" + params + "m"
+}
+
+// newStyle returns a style opening with params and closing with reset.
+func newStyle(params string, reset int) Style {
+	return Style{open: sgr(params), close: sgr(strconv.Itoa(reset))}
+}
+
+// resetForeground restores the default foreground color.
+const resetForeground = 39
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// The 8 basic foreground colors.
+var (
+	Black   = newStyle("30", resetForeground)
+	Red     = newStyle("31", resetForeground)
+	Green   = newStyle("32", resetForeground)
+	Yellow  = newStyle("33", resetForeground)
+	Blue    = newStyle("34", resetForeground)
+	Magenta = newStyle("35", resetForeground)
+	Cyan    = newStyle("36", resetForeground)
+	White   = newStyle("37", resetForeground)
+)
+
+// Text styles.
+var (
+	Bold      = newStyle("1", 22)
+	Dim       = newStyle("2", 22)
+	Underline = newStyle("4", 24)
+)
+
+// Color16 returns one of the 16 basic foreground colors: 0-7 are black, red,
+// green, yellow, blue, magenta, cyan and white, 8-15 their bright variants.
+// Other values are taken modulo 16.
+func Color16(n int) Style {
+	n = ((n % 16) + 16) % 16
+	if n < 8 {
+		return newStyle(strconv.Itoa(30+n), resetForeground)
+	}
+	return newStyle(strconv.Itoa(90+n-8), resetForeground)
+}
+
+// Color256 returns a foreground color of the 256-color palette.
+func Color256(n uint8) Style {
+	return newStyle("38;5;"+strconv.Itoa(int(n)), resetForeground)
+}
+
+// RGB returns a 24-bit truecolor foreground.
+func RGB(r, g, b uint8) Style {
+	return newStyle("38;2;"+strconv.Itoa(int(r))+";"+strconv.Itoa(int(g))+";"+strconv.Itoa(int(b)), resetForeground)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// escape matches CSI escape sequences, SGR included.
+var escape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// Strip removes ANSI escape sequences from s.
+func Strip(s string) string {
+	return escape.ReplaceAllString(s, "")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
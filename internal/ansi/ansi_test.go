@@ -0,0 +1,44 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package ansi
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import "testing"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestPaint(t *testing.T) {
+	cases := map[string]struct {
+		got, want string
+	}{
+		"red":       {Red.Paint("x"), "\x1b[31mx\x1b[39m"},
+		"white":     {White.Paint("x"), "\x1b[37mx\x1b[39m"},
+		"bold":      {Bold.Paint("x"), "\x1b[1mx\x1b[22m"},
+		"dim":       {Dim.Paint("x"), "\x1b[2mx\x1b[22m"},
+		"underline": {Underline.Paint("x"), "\x1b[4mx\x1b[24m"},
+		"bold red":  {Combine(Bold, Red).Paint("x"), "\x1b[1m\x1b[31mx\x1b[39m\x1b[22m"},
+		"color16":   {Color16(3).Paint("x"), Yellow.Paint("x")},
+		"bright":    {Color16(9).Paint("x"), "\x1b[91mx\x1b[39m"},
+		"color256":  {Color256(208).Paint("x"), "\x1b[38;5;208mx\x1b[39m"},
+		"rgb":       {RGB(255, 0, 128).Paint("x"), "\x1b[38;2;255;0;128mx\x1b[39m"},
+		"zero":      {Style{}.Paint("x"), "x"},
+	}
+	for name, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %q; want %q", name, c.got, c.want)
+		}
+	}
+	if Combine(Bold, Red).Paint("x") != Bold.Paint(Red.Paint("x")) {
+		t.Error("Combine should nest styles outermost first")
+	}
+}
+
+func TestStrip(t *testing.T) {
+	s := Combine(Bold, Red).Paint("red") + " " + RGB(1, 2, 3).Paint("x") + "\x1b[0m"
+	if got := Strip(s); got != "red x" {
+		t.Errorf("Strip = %q", got)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,196 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"encoding/json"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MarshalJSON ensures Err is emitted as its Error() string, not an object,
+// and adds the symbolized Frames next to the raw Stack. Details pass through
+// the package-wide Redactor.
+//
+// The encoding preserves the whole chain: when Err contains another Herror it
+// is emitted recursively under "Cause". Frames shared with the Cause layer are
+// left out and counted in "SharedFrames", so a deep chain does not repeat the
+// same stack at every level. When Err branches (errors.Join, MultiError),
+// every branch is emitted under "Causes" instead: an Herror branch as an
+// object, any other error as its text. UnmarshalJSON rebuilds an equivalent
+// chain or tree.
+func (h *Herror) MarshalJSON() ([]byte, error) {
+	type alias Herror
+	// if there’s no inner error, marshal it as empty string
+	errMsg := ""
+	if h.Err != nil {
+		errMsg = h.Err.Error()
+	}
+
+	frames := h.Frames()
+	shared := 0
+	var cause *Herror
+	var causes []any
+	if joined, ok := h.Err.(interface{ Unwrap() []error }); ok {
+		for _, branch := range joined.Unwrap() {
+			if branch == nil {
+				continue
+			}
+			if inner, ok := AsHerror(branch); ok {
+				causes = append(causes, inner)
+			} else {
+				causes = append(causes, branch.Error())
+			}
+		}
+	} else if h.Err != nil {
+		if inner, ok := AsHerror(h.Err); ok && inner != h {
+			cause = inner
+			shared = sharedSuffix(frames, inner.Frames())
+			frames = frames[:len(frames)-shared]
+		}
+	}
+
+	return json.Marshal(&struct {
+		Err string `json:"Err"`
+		*alias
+		Code         string         `json:"Code,omitempty"`
+		Help         string         `json:"Help,omitempty"`
+		Severity     Severity       `json:"Severity,omitempty"`
+		Details      map[string]any `json:"Details"`
+		Frames       []Frame        `json:"Frames,omitempty"`
+		SharedFrames int            `json:"SharedFrames,omitempty"`
+		Cause        *Herror        `json:"Cause,omitempty"`
+		Causes       []any          `json:"Causes,omitempty"`
+	}{
+		Err:          errMsg,
+		alias:        (*alias)(h),
+		Code:         h.Code,
+		Help:         h.HelpURL(),
+		Severity:     h.Severity,
+		Details:      h.redactedDetails(),
+		Frames:       frames,
+		SharedFrames: shared,
+		Cause:        cause,
+		Causes:       causes,
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// UnmarshalJSON rebuilds an Herror encoded by MarshalJSON, including every
+// nested Cause layer and every branch listed under Causes, so Operation, Category, GetDetail and errors.As keep
+// working on errors received from another process. The raw Stack program
+// counters are meaningless outside the process that captured them and are
+// dropped; Frames and StackTrace use the decoded frames instead. Numbers in
+// Details decode as float64, as with any JSON object.
+func (h *Herror) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Op           string
+		Message      string
+		Err          string
+		Details      map[string]any
+		Category     string
+		Code         string
+		Help         string
+		Severity     Severity
+		Frames       []Frame
+		SharedFrames int
+		Cause        *Herror
+		Causes       []json.RawMessage
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	frames := wire.Frames
+	var cause error
+	switch {
+	case wire.Cause != nil:
+		inner := wire.Cause.RawFrames()
+		if n := wire.SharedFrames; n > 0 && n <= len(inner) {
+			frames = append(frames, inner[len(inner)-n:]...)
+		}
+		cause = wire.Cause
+		if wire.Err != wire.Cause.Error() {
+			// a non-Herror wrapper sat between the two layers; keep its text
+			cause = &remoteError{msg: wire.Err, cause: wire.Cause}
+		}
+	case len(wire.Causes) > 0:
+		branches := make([]error, 0, len(wire.Causes))
+		for _, raw := range wire.Causes {
+			var text string
+			if json.Unmarshal(raw, &text) == nil {
+				branches = append(branches, &remoteError{msg: text})
+				continue
+			}
+			inner := &Herror{}
+			if err := json.Unmarshal(raw, inner); err != nil {
+				return err
+			}
+			branches = append(branches, inner)
+		}
+		cause = &remoteJoinError{msg: wire.Err, errs: branches}
+	case wire.Err != "":
+		cause = &remoteError{msg: wire.Err}
+	}
+
+	if wire.Details == nil {
+		wire.Details = make(map[string]any)
+	}
+	var def *Sentinel
+	if wire.Help != "" {
+		// keep the help link; the rest of the definition stays in the sender
+		def = &Sentinel{Code: wire.Code, HelpURL: wire.Help}
+	}
+	*h = Herror{
+		Op:       wire.Op,
+		Message:  wire.Message,
+		Err:      cause,
+		Details:  wire.Details,
+		Category: wire.Category,
+		Code:     wire.Code,
+		Severity: wire.Severity,
+		frames:   frames,
+		def:      def,
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DecodeHerror decodes a chain produced by MarshalJSON or JSONFormatter.
+func DecodeHerror(data []byte) (*Herror, error) {
+	h := &Herror{}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, NewCategorizedHerror("decode herror", "dataerr", "invalid Herror JSON", err, nil)
+	}
+	return h, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// remoteError stands in for a non-Herror error decoded from JSON: only its
+// message survives the trip.
+type remoteError struct {
+	msg   string
+	cause error
+}
+
+func (e *remoteError) Error() string { return e.msg }
+
+func (e *remoteError) Unwrap() error { return e.cause }
+
+// remoteJoinError stands in for a branching cause (errors.Join, MultiError)
+// decoded from JSON: its message and its branches survive the trip.
+type remoteJoinError struct {
+	msg  string
+	errs []error
+}
+
+func (e *remoteJoinError) Error() string { return e.msg }
+
+func (e *remoteJoinError) Unwrap() []error { return e.errs }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
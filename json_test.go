@@ -0,0 +1,117 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestHerror_JSONRoundTrip(t *testing.T) {
+	root := errors.New("no such file")
+	inner := NewCategorizedHerror("open", "io", "cannot open", root, map[string]any{"path": "/etc/app.cfg"})
+	middle := fmt.Errorf("reading: %w", inner)
+	outer := PropagateErr("load config", "config", "unable to load configuration", middle, map[string]any{"attempt": 2})
+
+	raw, err := json.Marshal(outer)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	decoded, err := DecodeHerror(raw)
+	if err != nil {
+		t.Fatalf("DecodeHerror failed: %v", err)
+	}
+
+	if decoded.Error() != outer.Error() {
+		t.Errorf("Error() changed in transit:\n got  %q\n want %q", decoded.Error(), outer.Error())
+	}
+	if op, _ := Operation(decoded); op != "load config" {
+		t.Errorf("Operation = %q; want %q", op, "load config")
+	}
+	if cat, _ := Category(decoded); cat != "config" {
+		t.Errorf("Category = %q; want %q", cat, "config")
+	}
+	if v, _ := GetDetail(decoded, "attempt"); v != float64(2) {
+		t.Errorf("GetDetail(attempt) = %v; want 2", v)
+	}
+
+	// the inner layer survives as a real *Herror
+	var innerDecoded *Herror
+	if !errors.As(decoded.Err, &innerDecoded) {
+		t.Fatalf("inner layer lost; Err = %T", decoded.Err)
+	}
+	if innerDecoded.Op != "open" || innerDecoded.Category != "io" || innerDecoded.Details["path"] != "/etc/app.cfg" {
+		t.Errorf("inner layer fields wrong: %+v", innerDecoded)
+	}
+	if innerDecoded.Err == nil || innerDecoded.Err.Error() != "no such file" {
+		t.Errorf("root cause = %v; want %q", innerDecoded.Err, "no such file")
+	}
+
+	// frames are rebuilt, including the ones shared with the inner layer
+	if got, want := len(decoded.Frames()), len(outer.(*Herror).Frames()); got != want {
+		t.Errorf("outer frames = %d; want %d", got, want)
+	}
+	if got, want := decoded.StackTrace(), outer.(*Herror).StackTrace(); got != want {
+		t.Errorf("StackTrace changed in transit:\n got:\n%s\n want:\n%s", got, want)
+	}
+	if got, want := innerDecoded.StackTrace(), inner.(*Herror).StackTrace(); got != want {
+		t.Errorf("inner StackTrace changed in transit")
+	}
+}
+
+func TestHerror_MarshalJSONSharedFrames(t *testing.T) {
+	outer := Wrap(NewHerror("inner", "", nil, nil), "outer", "")
+
+	var m struct {
+		Frames       []Frame
+		SharedFrames int
+		Cause        struct{ Op string }
+	}
+	if err := json.Unmarshal([]byte(JSONFormatter(outer.(*Herror))), &m); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if m.Cause.Op != "inner" {
+		t.Errorf("Cause.Op = %q; want inner", m.Cause.Op)
+	}
+	if m.SharedFrames == 0 || len(m.Frames)+m.SharedFrames != len(outer.(*Herror).Frames()) {
+		t.Errorf("Frames %d + SharedFrames %d do not cover the outer stack", len(m.Frames), m.SharedFrames)
+	}
+}
+
+func TestHerror_JSONBranches(t *testing.T) {
+	a := NewCategorizedHerror("a", "validation", "bad name", nil, nil)
+	b := NewHerror("b", "bad age", errors.New("negative"), nil)
+	for name, cause := range map[string]error{
+		"errors.Join": errors.Join(a, b, errors.New("disk full")),
+		"MultiError":  NewMultiError(a, b, errors.New("disk full")),
+	} {
+		top := NewHerror("top", "batch failed", cause, nil).(*Herror)
+		decoded, err := DecodeHerror([]byte(JSONFormatter(top)))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, ok := FindHerror(decoded, func(h *Herror) bool { return h.Op == "b" }); !ok {
+			t.Errorf("%s: branch b lost in transit", name)
+		}
+		if got := RootCauses(decoded); len(got) != 3 || got[2].Error() != "disk full" {
+			t.Errorf("%s: root causes = %v", name, got)
+		}
+		if decoded.Err.Error() != cause.Error() || FormatTree(decoded) != FormatTree(top) {
+			t.Errorf("%s: tree changed in transit:\n%s\nwant\n%s", name, FormatTree(decoded), FormatTree(top))
+		}
+	}
+}
+
+func TestDecodeHerror_Invalid(t *testing.T) {
+	if _, err := DecodeHerror([]byte("{not json")); err == nil {
+		t.Error("DecodeHerror should fail on invalid JSON")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
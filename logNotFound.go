@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-
-	"github.com/ttacon/chalk"
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -22,7 +20,8 @@ type logNotFoundConfig struct {
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // LogNotFound returns a NotFoundAction that logs a warning when a resource
-// isn’t found.  By default it prints to stderr in yellow:
+// isn’t found.  By default it prints to stderr, in the Theme's Warning style
+// (yellow) when stderr is a terminal:
 //
 //	Warning: Data address '...' not found. Context: ...
 func LogNotFound(contextMsg string, opts ...LogNotFoundOption) NotFoundAction {
@@ -36,8 +35,8 @@ func LogNotFound(contextMsg string, opts ...LogNotFoundOption) NotFoundAction {
 
 	return func(address string) (bool, error) {
 		msg := fmt.Sprintf(cfg.template, address, contextMsg)
-		msg = chalk.Yellow.Color(msg)
-		fmt.Fprintln(cfg.writer, msg)
+		msg = paint(GetTheme().Warning, msg)
+		fmt.Fprintln(NewColorWriter(cfg.writer), msg)
 		return false, nil
 	}
 }
@@ -0,0 +1,145 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LogfmtOption customizes NewLogfmtFormatter.
+type LogfmtOption func(*logfmtConfig)
+
+type logfmtConfig struct {
+	stack bool
+}
+
+// LogfmtStack adds a "stack" key holding the compact stack
+// ("function file:line" entries separated by "; ").
+func LogfmtStack() LogfmtOption {
+	return func(cfg *logfmtConfig) {
+		cfg.stack = true
+	}
+}
+
+// NewLogfmtFormatter returns a FormatterFunc that renders an Herror as a
+// single logfmt line. Keys come in a stable order: op, message, category,
+// severity, code, cause, then the details sorted by key under "details.",
+// nested maps flattened with dotted keys, and finally the stack if enabled:
+//
+//	op="load config" message="file missing" category=config cause="open app.yaml: no such file or directory" details.path=app.yaml
+//
+// Values containing spaces, quotes, '=' or control characters are quoted with
+// Go escaping, so a line never spans several lines. Details pass through the
+// package-wide Redactor.
+func NewLogfmtFormatter(opts ...LogfmtOption) FormatterFunc {
+	var cfg logfmtConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(h *Herror) string {
+		var b strings.Builder
+		writeLogfmt(&b, "op", h.Op)
+		if h.Message != "" {
+			writeLogfmt(&b, "message", h.Message)
+		}
+		if h.Category != "" {
+			writeLogfmt(&b, "category", h.Category)
+		}
+		if h.Severity != SeverityUnset {
+			writeLogfmt(&b, "severity", h.Severity.String())
+		}
+		if h.Code != "" {
+			writeLogfmt(&b, "code", h.Code)
+		}
+		if h.Err != nil {
+			writeLogfmt(&b, "cause", h.Err.Error())
+		}
+		writeLogfmtDetails(&b, "details", h.redactedDetails())
+		if cfg.stack && h.HasStack() {
+			writeLogfmt(&b, "stack", strings.Join(compactStack(h), "; "))
+		}
+		return b.String()
+	}
+}
+
+// LogfmtFormatter renders an Herror as a single logfmt line without the stack.
+// Use NewLogfmtFormatter(LogfmtStack()) to include it.
+func LogfmtFormatter(h *Herror) string {
+	return NewLogfmtFormatter()(h)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// writeLogfmtDetails writes details sorted by key, descending into nested
+// maps of any key and value type with dotted keys.
+func writeLogfmtDetails(b *strings.Builder, prefix string, details map[string]any) {
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		key := prefix + "." + k
+		if rv := reflect.ValueOf(details[k]); rv.Kind() == reflect.Map && rv.Len() > 0 {
+			nested := make(map[string]any, rv.Len())
+			for iter := rv.MapRange(); iter.Next(); {
+				nested[fmt.Sprint(iter.Key().Interface())] = iter.Value().Interface()
+			}
+			writeLogfmtDetails(b, key, nested)
+			continue
+		}
+		writeLogfmt(b, key, fmt.Sprintf("%v", details[k]))
+	}
+}
+
+// writeLogfmt appends one key=value pair, separated from the previous pair.
+func writeLogfmt(b *strings.Builder, key, value string) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(logfmtKey(key))
+	b.WriteByte('=')
+	if logfmtNeedsQuote(value) {
+		b.WriteString(strconv.Quote(value))
+	} else {
+		b.WriteString(value)
+	}
+}
+
+// logfmtKey replaces the characters a key cannot contain with underscores.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// logfmtNeedsQuote reports whether value must be quoted: it is empty or
+// contains a space, '=', a quote, a backslash or a non-printable character.
+func logfmtNeedsQuote(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
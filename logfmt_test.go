@@ -0,0 +1,79 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestLogfmtFormatter(t *testing.T) {
+	h, _ := AsHerror(NewSeverityHerror("load config", "config", SeverityError, "file missing",
+		errors.New("open app.yaml: no such file"),
+		map[string]any{
+			"path":     "app.yaml",
+			"attempts": 3,
+			"user":     map[string]any{"name": "ada lovelace", "id": 7},
+			"note":     "line1\nline2 \"quoted\"",
+			"empty":    "",
+			"token":    "abc",
+		}))
+
+	want := `op="load config" message="file missing" category=config severity=error` +
+		` cause="open app.yaml: no such file"` +
+		` details.attempts=3 details.empty="" details.note="line1\nline2 \"quoted\""` +
+		` details.path=app.yaml details.token=[REDACTED] details.user.id=7 details.user.name="ada lovelace"`
+	if got := LogfmtFormatter(h); got != want {
+		t.Errorf("LogfmtFormatter =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Contains(LogfmtFormatter(h), "\n") {
+		t.Error("logfmt output must stay on one line")
+	}
+}
+
+func TestLogfmtFormatter_Minimal(t *testing.T) {
+	h := &Herror{Op: "op", Code: "X_1"}
+	if got := LogfmtFormatter(h); got != "op=op code=X_1" {
+		t.Errorf("LogfmtFormatter = %q", got)
+	}
+	h = &Herror{Details: map[string]any{"a key=": "v"}}
+	if got := LogfmtFormatter(h); got != `op="" details.a_key_=v` {
+		t.Errorf("keys should be sanitized: %q", got)
+	}
+}
+
+func TestLogfmtFormatter_TypedNestedMaps(t *testing.T) {
+	h := &Herror{Op: "op", Details: map[string]any{
+		"hdrs":  map[string]string{"Accept": "json", "X-Trace": "a b"},
+		"ports": map[int]bool{80: true},
+	}}
+	want := `op=op details.hdrs.Accept=json details.hdrs.X-Trace="a b" details.ports.80=true`
+	if got := LogfmtFormatter(h); got != want {
+		t.Errorf("LogfmtFormatter =\n%s\nwant\n%s", got, want)
+	}
+
+	// flattened as given, before any redaction rewrites the maps
+	var b strings.Builder
+	writeLogfmtDetails(&b, "details", map[string]any{"hdrs": map[string]string{"Accept": "json"}})
+	if got := b.String(); got != "details.hdrs.Accept=json" {
+		t.Errorf("typed map not flattened: %s", got)
+	}
+}
+
+func TestNewLogfmtFormatter_Stack(t *testing.T) {
+	h, _ := AsHerror(NewHerror("op", "msg", nil, nil))
+	if strings.Contains(LogfmtFormatter(h), "stack=") {
+		t.Error("stack should be off by default")
+	}
+	got := NewLogfmtFormatter(LogfmtStack())(h)
+	if !strings.Contains(got, ` stack="`) || !strings.Contains(got, "logfmt_test.go:") || strings.Contains(got, "\n") {
+		t.Errorf("stack missing or multi-line: %s", got)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
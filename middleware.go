@@ -0,0 +1,97 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RecoverMiddleware wraps next so that a panic in it becomes an *Herror
+// instead of a dropped connection. The recovered error carries the panic-site
+// stack and the request method, path, remote address and request ID as
+// Details; it is counted in the registry and reported in full (through the
+// FormatterFunc to the writer, or to the slog logger), while the client only
+// receives a generic 500 problem response. If next had already started the
+// response, it is left as is and the error is only reported.
+//
+// http.ErrAbortHandler is re-panicked, as net/http expects.
+func RecoverMiddleware(next http.Handler, opts ...HTTPOption) http.Handler {
+	cfg := defaultHTTPConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		w := &trackingWriter{ResponseWriter: rw}
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			// copy so a *Herror panic payload owned by the caller is not mutated
+			herr := *recoveredHerror(rec)
+			herr.Details = cloneDetails(herr.Details)
+			if herr.Details == nil {
+				herr.Details = make(map[string]any)
+			}
+			herr.Details["method"] = r.Method
+			herr.Details["path"] = r.URL.Path
+			herr.Details["remote_addr"] = r.RemoteAddr
+			if id := r.Header.Get(cfg.requestID); id != "" {
+				herr.Details["request_id"] = id
+			}
+
+			cfg.report(r.Context(), "panic recovered", &herr)
+			if w.wrote {
+				return
+			}
+			writeProblem(w, &Problem{
+				Type:     "about:blank",
+				Title:    http.StatusText(http.StatusInternalServerError),
+				Status:   http.StatusInternalServerError,
+				Instance: r.URL.RequestURI(),
+			})
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// trackingWriter records whether the handler started its response.
+type trackingWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (t *trackingWriter) WriteHeader(code int) {
+	t.wrote = true
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *trackingWriter) Write(b []byte) (int, error) {
+	t.wrote = true
+	return t.ResponseWriter.Write(b)
+}
+
+// Flush keeps streaming handlers working through the wrapper.
+func (t *trackingWriter) Flush() {
+	if f, ok := t.ResponseWriter.(http.Flusher); ok {
+		t.wrote = true
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (t *trackingWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,120 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestRecoverMiddleware(t *testing.T) {
+	logBuf := &bytes.Buffer{}
+	reg := NewRegistry()
+	h := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok" {
+			w.Write([]byte("fine"))
+			return
+		}
+		panic("db password is hunter2")
+	}), WithHTTPWriter(logBuf), WithHTTPRegistry(reg))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/ok", nil))
+	if rec.Code != 200 || rec.Body.String() != "fine" || logBuf.Len() != 0 {
+		t.Errorf("successful handler altered: %d %q (log %q)", rec.Code, rec.Body.String(), logBuf.String())
+	}
+
+	req := httptest.NewRequest("POST", "/boom?x=1", nil)
+	req.Header.Set("X-Request-ID", "req-7")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != 500 || rec.Header().Get("Content-Type") != ProblemContentType {
+		t.Errorf("response = %d %q; want 500 problem+json", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if strings.Contains(rec.Body.String(), "hunter2") {
+		t.Errorf("response leaks the panic value: %s", rec.Body.String())
+	}
+
+	var logged map[string]any
+	if err := json.Unmarshal(logBuf.Bytes(), &logged); err != nil {
+		t.Fatalf("log is not the JSON report: %v\n%s", err, logBuf.String())
+	}
+	details, _ := logged["Details"].(map[string]any)
+	if logged["Category"] != PanicCategory || logged["Message"] != "db password is hunter2" {
+		t.Errorf("logged error = %v", logged)
+	}
+	if details["method"] != "POST" || details["path"] != "/boom" || details["request_id"] != "req-7" || details["remote_addr"] == "" {
+		t.Errorf("logged details = %v", details)
+	}
+	if !strings.Contains(logBuf.String(), "middleware_test.go") {
+		t.Errorf("logged stack should point at the panic site:\n%s", logBuf.String())
+	}
+	if got := reg.Counts()[PanicCategory]; got != 1 {
+		t.Errorf("registry count = %d; want 1", got)
+	}
+}
+
+func TestRecoverMiddleware_PartialResponse(t *testing.T) {
+	logBuf := &bytes.Buffer{}
+	reg := NewRegistry()
+	h := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("partial"))
+		panic("late failure")
+	}), WithHTTPWriter(logBuf), WithHTTPRegistry(reg))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/stream", nil))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "partial" {
+		t.Errorf("started response altered: %d %q", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(logBuf.String(), "late failure") || reg.Counts()[PanicCategory] != 1 {
+		t.Errorf("panic should still be reported: %q", logBuf.String())
+	}
+}
+
+func TestRecoverMiddleware_HerrorPayloadAndLogger(t *testing.T) {
+	payload, _ := AsHerror(NewCategorizedHerror("work", "internal", "bad state", nil, map[string]any{"k": "v"}))
+	logBuf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(logBuf, nil))
+	h := RecoverMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(payload)
+	}), WithHTTPLogger(logger), WithHTTPRegistry(nil))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/x", nil))
+	if rec.Code != 500 {
+		t.Errorf("status = %d; want 500", rec.Code)
+	}
+	if len(payload.Details) != 1 {
+		t.Errorf("panic payload was mutated: %v", payload.Details)
+	}
+	got := logBuf.String()
+	if !strings.Contains(got, `"msg":"panic recovered"`) || !strings.Contains(got, `"op":"work"`) || !strings.Contains(got, `"path":"/x"`) {
+		t.Errorf("slog record = %s", got)
+	}
+}
+
+func TestRecoverMiddleware_AbortHandler(t *testing.T) {
+	h := RecoverMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), WithHTTPWriter(nil))
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("recovered %v; want http.ErrAbortHandler re-panicked", r)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
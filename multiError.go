@@ -0,0 +1,134 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// uncategorized labels MultiError members without a category.
+const uncategorized = "uncategorized"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MultiError aggregates several errors, e.g. every failure of a batch
+// validation, so they can be reported at once. It is safe for concurrent use.
+//
+// Unwrap returns the members, so errors.Is and errors.As see all of them.
+// Passed to CheckErr, Report or Warn, each member is registered and rendered
+// through the configured FormatterFunc on its own, and an empty MultiError
+// is treated like a nil error.
+type MultiError struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewMultiError returns a MultiError holding errs (nil errors are skipped).
+func NewMultiError(errs ...error) *MultiError {
+	m := &MultiError{}
+	m.Add(errs...)
+	return m
+}
+
+// Add appends the non-nil errs. The members of another MultiError are added
+// individually instead of nesting it. It is safe for concurrent calls.
+func (m *MultiError) Add(errs ...error) {
+	var flat []error
+	for _, err := range errs {
+		switch e := err.(type) {
+		case nil:
+		case *MultiError:
+			if e != m {
+				flat = append(flat, e.Errors()...)
+			}
+		default:
+			flat = append(flat, err)
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs = append(m.errs, flat...)
+}
+
+// Len returns the number of collected errors.
+func (m *MultiError) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.errs)
+}
+
+// Errors returns a copy of the collected errors, in insertion order.
+func (m *MultiError) Errors() []error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]error(nil), m.errs...)
+}
+
+// Unwrap returns the collected errors for errors.Is and errors.As.
+func (m *MultiError) Unwrap() []error {
+	return m.Errors()
+}
+
+// ErrorOrNil returns m if it holds any error and nil otherwise, so a batch
+// can end with `return merr.ErrorOrNil()`.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || m.Len() == 0 {
+		return nil
+	}
+	return m
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Error lists the members grouped by Category, groups in order of first
+// appearance and uncategorized errors last:
+//
+//	3 errors occurred:
+//	[validation] 2
+//	  - operation 'parse' failed: name is required
+//	  - operation 'parse' failed: age is negative
+//	[uncategorized] 1
+//	  - disk full
+func (m *MultiError) Error() string {
+	errs := m.Errors()
+	switch len(errs) {
+	case 0:
+		return "no errors"
+	case 1:
+		return errs[0].Error()
+	}
+
+	var order []string
+	groups := make(map[string][]error)
+	for _, err := range errs {
+		category := uncategorized
+		if c, ok := Category(err); ok && c != "" {
+			category = c
+		}
+		if _, seen := groups[category]; !seen && category != uncategorized {
+			order = append(order, category)
+		}
+		groups[category] = append(groups[category], err)
+	}
+	if _, ok := groups[uncategorized]; ok {
+		order = append(order, uncategorized)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d errors occurred:", len(errs))
+	for _, category := range order {
+		fmt.Fprintf(&b, "\n[%s] %d", category, len(groups[category]))
+		for _, err := range groups[category] {
+			b.WriteString("\n  - " + err.Error())
+		}
+	}
+	return b.String()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,151 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"strings"
+	"sync"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestMultiError_AddConcurrent(t *testing.T) {
+	m := NewMultiError()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Add(errors.New("x"), nil)
+		}()
+	}
+	wg.Wait()
+	if m.Len() != 50 {
+		t.Errorf("Len = %d; want 50", m.Len())
+	}
+
+	m.Add(NewMultiError(errors.New("a"), errors.New("b")))
+	if m.Len() != 52 {
+		t.Errorf("nested MultiError should be flattened; Len = %d", m.Len())
+	}
+}
+
+func TestMultiError_IsAs(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	m := NewMultiError(
+		errors.New("plain"),
+		Wrap(sentinel, "load", "failed"),
+		&fs.PathError{Op: "open", Path: "/x", Err: fs.ErrNotExist},
+	)
+	if !errors.Is(m, sentinel) || !errors.Is(m, fs.ErrNotExist) {
+		t.Error("errors.Is should see every member")
+	}
+	var pe *fs.PathError
+	if !errors.As(m, &pe) || pe.Path != "/x" {
+		t.Error("errors.As should find the PathError member")
+	}
+	if h, ok := AsHerror(m); !ok || h.Op != "load" {
+		t.Errorf("AsHerror = %v, %v", h, ok)
+	}
+}
+
+func TestMultiError_Error(t *testing.T) {
+	if got := NewMultiError().Error(); got != "no errors" {
+		t.Errorf("empty Error() = %q", got)
+	}
+	if got := NewMultiError(errors.New("only")).Error(); got != "only" {
+		t.Errorf("single Error() = %q", got)
+	}
+
+	m := NewMultiError(
+		errors.New("disk full"),
+		NewCategorizedHerror("parse", "validation", "name is required", nil, nil),
+		NewCategorizedHerror("open", "io", "cannot open", nil, nil),
+		NewCategorizedHerror("parse", "validation", "age is negative", nil, nil),
+	)
+	want := "4 errors occurred:\n" +
+		"[validation] 2\n" +
+		"  - operation 'parse' failed: name is required [category: validation]\n" +
+		"  - operation 'parse' failed: age is negative [category: validation]\n" +
+		"[io] 1\n" +
+		"  - operation 'open' failed: cannot open [category: io]\n" +
+		"[uncategorized] 1\n" +
+		"  - disk full"
+	if got := m.Error(); got != want {
+		t.Errorf("Error() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMultiError_ErrorOrNil(t *testing.T) {
+	var nilMulti *MultiError
+	if nilMulti.ErrorOrNil() != nil || NewMultiError(nil).ErrorOrNil() != nil {
+		t.Error("empty MultiError should yield a nil error")
+	}
+	if NewMultiError(errors.New("x")).ErrorOrNil() == nil {
+		t.Error("non-empty MultiError should yield itself")
+	}
+}
+
+func TestMultiError_CheckErr(t *testing.T) {
+	buf := &bytes.Buffer{}
+	code := 0
+	r := NewReporter(WithWriter(buf), WithExitFunc(func(c int) { code = c }), WithFormatter(JSONFormatter))
+	r.CheckErr(NewMultiError(
+		NewCategorizedHerror("parse", "validation", "bad name", nil, nil),
+		errors.New("disk full"),
+	), WithOp("batch"))
+
+	if code != 1 {
+		t.Errorf("exit code = %d; want 1", code)
+	}
+	dec := json.NewDecoder(buf)
+	var ops []string
+	for dec.More() {
+		var m map[string]any
+		if err := dec.Decode(&m); err != nil {
+			t.Fatalf("member output is not JSON: %v", err)
+		}
+		ops = append(ops, m["Op"].(string)+"/"+m["Err"].(string))
+	}
+	if strings.Join(ops, ",") != "parse/,batch/disk full" {
+		t.Errorf("rendered members = %v", ops)
+	}
+	if got := r.ErrorRegistry(); got["validation"] != 1 || got["unknown"] != 1 {
+		t.Errorf("registry = %v; want each member counted", got)
+	}
+}
+
+func TestMultiError_CheckErrEmptyAndWrapped(t *testing.T) {
+	buf := &bytes.Buffer{}
+	code := 0
+	r := NewReporter(WithWriter(buf), WithExitFunc(func(c int) { code = c }), WithFormatter(func(h *Herror) string { return h.Error() }))
+
+	r.CheckErr(NewMultiError())
+	if code != 0 || buf.Len() != 0 || r.Report(NewMultiError()) != nil {
+		t.Errorf("empty MultiError should be ignored: exit %d, output %q", code, buf.String())
+	}
+
+	merr := NewMultiError(NewHerror("row", "bad row", nil, nil), errors.New("disk full"))
+	r.CheckErr(PropagateErr("import batch", "io", "batch failed", merr, nil))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if code == 0 || len(lines) != 2 {
+		t.Fatalf("exit %d, output:\n%s", code, buf.String())
+	}
+	for i, cause := range []string{"bad row", "disk full"} {
+		if !strings.HasPrefix(lines[i], "operation 'import batch' failed: batch failed") || !strings.Contains(lines[i], cause) {
+			t.Errorf("member %d lost the wrapping context: %q", i, lines[i])
+		}
+	}
+	if got := r.ErrorRegistry()["io"]; got != 2 {
+		t.Errorf("registry = %v; want both members counted under the wrapper", r.ErrorRegistry())
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
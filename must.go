@@ -6,8 +6,7 @@ package horus
 
 // Must returns the value v if err is nil; otherwise it calls CheckErr(err) and exits.
 func Must[T any](v T, err error) T {
-	CheckErr(err)
-	return v
+	return MustWith(Default(), v, err)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
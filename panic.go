@@ -0,0 +1,110 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"runtime"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PanicCategory is the category assigned to errors built from recovered panics.
+const PanicCategory = "panic"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Panic prints a colored panic banner through the default Reporter, then
+// panics with an *Herror carrying the operation, message and caller's stack.
+func Panic(op, message string) {
+	Default().panicAt(1, op, message)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Recover is meant to be deferred. If the surrounding function panics, the
+// recovered value is converted into an *Herror and handed to handler instead
+// of crashing the goroutine:
+//
+//	defer horus.Recover(func(err error) { log.Println(err) })
+func Recover(handler func(error)) {
+	if r := recover(); r != nil {
+		herr := recoveredHerror(r)
+		if handler != nil {
+			handler(herr)
+		}
+	}
+}
+
+// RecoverTo is meant to be deferred from a function with a named error result.
+// If the function panics, the recovered value is converted into an *Herror and
+// stored in *errp, turning the panic into a normal error return:
+//
+//	func work() (err error) {
+//		defer horus.RecoverTo(&err)
+//		...
+//	}
+func RecoverTo(errp *error) {
+	if r := recover(); r != nil {
+		herr := recoveredHerror(r)
+		if errp != nil {
+			*errp = herr
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// recoveredHerror converts a value returned by recover() into an *Herror.
+// It must be called directly from the deferred function that recovered, so
+// that the captured stack still contains the panicking frames.
+//
+//   - *Herror values are returned unchanged (they already carry a stack).
+//   - errors become the cause of a new panic Herror.
+//   - strings become the message of a new panic Herror.
+//   - anything else is printed with %v and its type recorded in Details.
+func recoveredHerror(r any) *Herror {
+	var herr *Herror
+	switch v := r.(type) {
+	case *Herror:
+		return v
+	case error:
+		herr = &Herror{Op: "recover", Message: "recovered from panic", Err: v}
+	case string:
+		herr = &Herror{Op: "recover", Message: v}
+	default:
+		herr = &Herror{
+			Op:      "recover",
+			Message: fmt.Sprintf("%v", v),
+			Details: map[string]any{"panic_type": fmt.Sprintf("%T", v)},
+		}
+	}
+	if herr.Details == nil {
+		herr.Details = make(map[string]any)
+	}
+	herr.Category = PanicCategory
+	herr.Stack = panicStack()
+	return herr
+}
+
+// panicStack captures the current stack and trims everything up to and
+// including runtime.gopanic, so that the first frame is the panic site rather
+// than the deferred recovery helpers.
+func panicStack() []uintptr {
+	sc := GetStackConfig()
+	if sc.Mode == StackOff {
+		return nil
+	}
+	sc.Mode = StackFull
+	pcs := sc.capture(nil, 0)
+	for i, pc := range pcs {
+		if fn := runtime.FuncForPC(pc - 1); fn != nil && fn.Name() == "runtime.gopanic" {
+			return pcs[i+1:]
+		}
+	}
+	return pcs
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,138 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestPanic_PayloadAndBanner(t *testing.T) {
+	buf := &bytes.Buffer{}
+	defer SetDefault(SetDefault(NewReporter(WithWriter(buf))))
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		Panic("DoIt", "it broke")
+	}()
+
+	if got := stripANSI(buf.String()); !strings.Contains(got, "Panic [DoIt]: it broke") {
+		t.Errorf("banner = %q; want it to contain %q", got, "Panic [DoIt]: it broke")
+	}
+
+	h, ok := recovered.(*Herror)
+	if !ok {
+		t.Fatalf("panic payload = %T; want *Herror", recovered)
+	}
+	if h.Op != "DoIt" || h.Message != "it broke" || h.Category != PanicCategory {
+		t.Errorf("payload fields wrong: %+v", h)
+	}
+	if !h.HasStack() {
+		t.Error("payload should carry a stack")
+	}
+	if first := h.Frames()[0]; !strings.Contains(first.Function, "TestPanic_PayloadAndBanner") || !strings.HasSuffix(first.File, "panic_test.go") {
+		t.Errorf("first frame = %s %s:%d; want the caller of Panic", first.Function, first.File, first.Line)
+	}
+
+	r := NewReporter(WithWriter(buf))
+	func() {
+		defer func() { recovered = recover() }()
+		r.Panic("DoIt", "it broke")
+	}()
+	if first := recovered.(*Herror).Frames()[0]; !strings.Contains(first.Function, "TestPanic_PayloadAndBanner") {
+		t.Errorf("Reporter.Panic first frame = %s; want the caller", first.Function)
+	}
+}
+
+func TestRecoverTo_ConvertsValues(t *testing.T) {
+	sentinel := errors.New("sentinel")
+
+	tests := []struct {
+		name    string
+		value   any
+		message string
+	}{
+		{"string", "kaboom", "kaboom"},
+		{"error", sentinel, "recovered from panic"},
+		{"other", 42, "42"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			work := func() (err error) {
+				defer RecoverTo(&err)
+				panic(tc.value)
+			}
+
+			err := work()
+			h, ok := AsHerror(err)
+			if !ok {
+				t.Fatalf("RecoverTo stored %T; want *Herror", err)
+			}
+			if h.Category != PanicCategory {
+				t.Errorf("Category = %q; want %q", h.Category, PanicCategory)
+			}
+			if h.Message != tc.message {
+				t.Errorf("Message = %q; want %q", h.Message, tc.message)
+			}
+			if tc.value == sentinel && !errors.Is(err, sentinel) {
+				t.Error("recovered error should wrap the panic value")
+			}
+			if tc.name == "other" && h.Details["panic_type"] != "int" {
+				t.Errorf("Details[panic_type] = %v; want int", h.Details["panic_type"])
+			}
+
+			// the first frame must be the panic site, not the recovery helpers
+			trace := h.StackTrace()
+			if strings.Contains(trace, "horus.RecoverTo\n") || strings.Contains(trace, "runtime.gopanic") {
+				t.Errorf("stack should start at the panic site; got:\n%s", trace)
+			}
+			if !strings.Contains(trace, "TestRecoverTo_ConvertsValues") {
+				t.Errorf("stack missing panicking function; got:\n%s", trace)
+			}
+		})
+	}
+}
+
+func TestRecoverTo_HerrorPassthrough(t *testing.T) {
+	orig := NewCategorizedHerror("op", "cat", "msg", nil, nil)
+
+	work := func() (err error) {
+		defer RecoverTo(&err)
+		panic(orig)
+	}
+
+	if err := work(); err != orig {
+		t.Errorf("RecoverTo = %v; want original *Herror", err)
+	}
+}
+
+func TestRecover_Handler(t *testing.T) {
+	var got error
+	func() {
+		defer Recover(func(err error) { got = err })
+		panic("boom")
+	}()
+
+	if msg, _ := UserMessage(got); msg != "boom" {
+		t.Errorf("handler received %v; want message %q", got, "boom")
+	}
+
+	// no panic → handler is not called
+	called := false
+	func() {
+		defer Recover(func(error) { called = true })
+	}()
+	if called {
+		t.Error("handler should not run without a panic")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,339 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ProblemContentType is the media type of RFC 9457 problem details.
+const ProblemContentType = "application/problem+json"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Problem is an RFC 9457 problem details object. Extensions are emitted as
+// additional top-level members; they never override the standard members.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+// MarshalJSON renders the standard members followed by the extensions.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	m["type"] = p.Type
+	m["title"] = p.Title
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	} else {
+		delete(m, "detail")
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	} else {
+		delete(m, "instance")
+	}
+	return json.Marshal(m)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DefaultHTTPStatuses returns the default category → HTTP status mapping.
+// Categories are matched case-insensitively; anything else is a 500.
+func DefaultHTTPStatuses() map[string]int {
+	return map[string]int{
+		"usage":        http.StatusBadRequest,
+		"validation":   http.StatusBadRequest,
+		"dataerr":      http.StatusBadRequest,
+		"bad_request":  http.StatusBadRequest,
+		"unauthorized": http.StatusUnauthorized,
+		"forbidden":    http.StatusForbidden,
+		"not_found":    http.StatusNotFound,
+		"noinput":      http.StatusNotFound,
+		"conflict":     http.StatusConflict,
+		"unavailable":  http.StatusServiceUnavailable,
+		"timeout":      http.StatusGatewayTimeout,
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HTTPOption customizes the HTTP error responder.
+type HTTPOption func(*httpConfig)
+
+type httpConfig struct {
+	statuses  map[string]int
+	typeBase  string
+	writer    io.Writer
+	formatter FormatterFunc
+	logger    *slog.Logger
+	registry  *Registry // nil with registrySet unset: Default()'s, looked up when reporting
+	requestID string
+
+	registrySet bool // WithHTTPRegistry was given, so a nil registry disables counting
+}
+
+func defaultHTTPConfig() httpConfig {
+	return httpConfig{
+		statuses:  DefaultHTTPStatuses(),
+		writer:    os.Stderr,
+		formatter: JSONFormatter,
+		requestID: "X-Request-ID",
+	}
+}
+
+// WithStatusCodes replaces the category → HTTP status mapping.
+func WithStatusCodes(statuses map[string]int) HTTPOption {
+	return func(cfg *httpConfig) {
+		cfg.statuses = make(map[string]int, len(statuses))
+		for k, v := range statuses {
+			cfg.statuses[strings.ToLower(k)] = v
+		}
+	}
+}
+
+// WithProblemTypeBase sets the URI prefix used for the problem "type"
+// member: categorized errors get base + "/" + category. Without it every
+// problem has type "about:blank".
+func WithProblemTypeBase(base string) HTTPOption {
+	return func(cfg *httpConfig) {
+		cfg.typeBase = strings.TrimSuffix(base, "/")
+	}
+}
+
+// WithHTTPWriter sets where full error reports are logged (defaults to stderr).
+// A nil writer disables logging.
+func WithHTTPWriter(w io.Writer) HTTPOption {
+	return func(cfg *httpConfig) {
+		cfg.writer = w
+	}
+}
+
+// WithHTTPFormatter sets the FormatterFunc used for logged error reports
+// (defaults to JSONFormatter).
+func WithHTTPFormatter(f FormatterFunc) HTTPOption {
+	return func(cfg *httpConfig) {
+		cfg.formatter = f
+	}
+}
+
+// WithHTTPLogger sends error reports to logger instead of the writer. The
+// error is logged as an attribute, so *Herror's LogValue (or a SlogHandler)
+// structures it.
+func WithHTTPLogger(logger *slog.Logger) HTTPOption {
+	return func(cfg *httpConfig) {
+		cfg.logger = logger
+	}
+}
+
+// WithHTTPRegistry sets the Registry errors are counted into (defaults to the
+// registry of the default Reporter at the time of the error). A nil Registry
+// disables counting.
+func WithHTTPRegistry(reg *Registry) HTTPOption {
+	return func(cfg *httpConfig) {
+		cfg.registry = reg
+		cfg.registrySet = true
+	}
+}
+
+// WithRequestIDHeader sets the request header whose value is recorded as the
+// "request_id" detail of recovered panics (defaults to X-Request-ID).
+func WithRequestIDHeader(name string) HTTPOption {
+	return func(cfg *httpConfig) {
+		cfg.requestID = name
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// status returns the HTTP status for err: the HTTPStatus of the error's
+// definition (see Catalog), then the first Herror category in the chain found
+// in the table, then 504 for deadline errors, else 500.
+func (cfg *httpConfig) status(err error) int {
+	if def := definition(err); def != nil && def.HTTPStatus != 0 {
+		return def.HTTPStatus
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if herr, ok := e.(*Herror); ok && herr.Category != "" {
+			if code, ok := cfg.statuses[strings.ToLower(herr.Category)]; ok {
+				return code
+			}
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// problem builds the client-facing Problem for err. Only the user-facing
+// Message, Details and Code are exposed; the underlying cause never is. A
+// definition's HelpURL becomes the problem type.
+func (cfg *httpConfig) problem(err error, r *http.Request) *Problem {
+	status := cfg.status(err)
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if r != nil {
+		p.Instance = r.URL.RequestURI()
+	}
+	if herr, ok := AsHerror(err); ok {
+		p.Detail = herr.Message
+		if len(herr.Details) > 0 {
+			p.Extensions = herr.redactedDetails()
+		}
+		if herr.Code != "" {
+			if p.Extensions == nil {
+				p.Extensions = make(map[string]any, 1)
+			}
+			p.Extensions["code"] = herr.Code
+		}
+		switch {
+		case herr.HelpURL() != "":
+			p.Type = herr.HelpURL()
+		case cfg.typeBase != "" && herr.Category != "":
+			p.Type = cfg.typeBase + "/" + strings.ToLower(herr.Category)
+		}
+	}
+	return p
+}
+
+// report registers err and logs the full error report, cause and stack
+// included, to the slog logger if one is set, otherwise to the writer.
+func (cfg *httpConfig) report(ctx context.Context, msg string, err error) {
+	cfg.currentRegistry().Register(err)
+	if cfg.logger != nil {
+		cfg.logger.ErrorContext(ctx, msg, "err", err)
+		return
+	}
+	if cfg.writer == nil {
+		return
+	}
+	herr, ok := AsHerror(err)
+	if !ok {
+		herr = newHerror("http handler", "", "", err, nil)
+	}
+	fmt.Fprintln(NewColorWriter(cfg.writer), cfg.formatter(herr))
+}
+
+// currentRegistry returns the configured registry, or the default Reporter's
+// when none was given, so a later SetDefault is honored.
+func (cfg *httpConfig) currentRegistry() *Registry {
+	if cfg.registrySet {
+		return cfg.registry
+	}
+	return Default().Registry()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewProblem builds the Problem for err using the default status mapping.
+// The underlying cause is not included.
+func NewProblem(err error, r *http.Request, opts ...HTTPOption) *Problem {
+	cfg := defaultHTTPConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg.problem(err, r)
+}
+
+// ProblemFormatter renders an Herror as an application/problem+json document.
+func ProblemFormatter(h *Herror) string {
+	out, err := json.MarshalIndent(NewProblem(h, nil), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("error formatting: %v", err)
+	}
+	return string(out)
+}
+
+// WriteProblem writes err to w as an application/problem+json response.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error, opts ...HTTPOption) {
+	writeProblem(w, NewProblem(err, r, opts...))
+}
+
+func writeProblem(w http.ResponseWriter, p *Problem) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	w.Write(body)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HandlerFunc is an HTTP handler that returns an error instead of writing
+// error responses itself. Used directly as an http.Handler it applies the
+// defaults; use HandleErrors to customize the responder.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// handlerFuncConfig is the default configuration HandlerFunc serves with,
+// built once rather than per request.
+var handlerFuncConfig = defaultHTTPConfig()
+
+// ServeHTTP calls fn and responds to a returned error with problem details.
+func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handlerFuncConfig.serve(fn, w, r)
+}
+
+// HandleErrors adapts fn to an http.Handler. A returned error is logged in
+// full through the configured FormatterFunc and answered with an RFC 9457
+// problem whose status comes from the error's Category.
+func HandleErrors(fn HandlerFunc, opts ...HTTPOption) http.Handler {
+	cfg := defaultHTTPConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &errorHandler{fn: fn, cfg: &cfg}
+}
+
+type errorHandler struct {
+	fn  HandlerFunc
+	cfg *httpConfig
+}
+
+func (h *errorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.cfg.serve(h.fn, w, r)
+}
+
+// serve calls fn and answers a returned error with a problem response. An
+// error returned after fn started its response is only reported: the
+// status is already sent, and appending a problem would corrupt the body.
+func (cfg *httpConfig) serve(fn HandlerFunc, rw http.ResponseWriter, r *http.Request) {
+	w := &trackingWriter{ResponseWriter: rw}
+	err := fn(w, r)
+	if err == nil {
+		return
+	}
+	cfg.report(r.Context(), "request failed", err)
+	if !w.wrote {
+		writeProblem(w, cfg.problem(err, r))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
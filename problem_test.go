@@ -0,0 +1,181 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	p := Problem{
+		Type:       "about:blank",
+		Title:      "Not Found",
+		Status:     404,
+		Extensions: map[string]any{"id": "42", "status": "ignored", "detail": "ignored"},
+	}
+	raw, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var m map[string]any
+	json.Unmarshal(raw, &m)
+
+	if m["type"] != "about:blank" || m["title"] != "Not Found" || m["status"] != float64(404) || m["id"] != "42" {
+		t.Errorf("problem members wrong: %v", m)
+	}
+	if _, ok := m["detail"]; ok {
+		t.Errorf("extensions must not override standard members: %v", m)
+	}
+	if _, ok := m["instance"]; ok {
+		t.Errorf("empty instance should be omitted: %v", m)
+	}
+}
+
+func TestNewProblem_StatusAndHiding(t *testing.T) {
+	secret := errors.New("pq: password authentication failed for user admin")
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"plain", secret, 500},
+		{"validation", NewCategorizedHerror("parse", "VALIDATION", "name is required", secret, nil), 400},
+		{"inner not_found", Wrap(NewCategorizedHerror("get", "not_found", "no such user", secret, nil), "handler", "lookup failed"), 404},
+		{"deadline", Wrap(context.DeadlineExceeded, "fetch", "upstream timed out"), 504},
+	}
+	for _, tc := range tests {
+		p := NewProblem(tc.err, nil)
+		if p.Status != tc.status || p.Title != http.StatusText(tc.status) {
+			t.Errorf("%s: status/title = %d %q; want %d", tc.name, p.Status, p.Title, tc.status)
+		}
+		raw, _ := json.Marshal(p)
+		if strings.Contains(string(raw), "password") {
+			t.Errorf("%s: problem leaks the internal cause: %s", tc.name, raw)
+		}
+	}
+
+	p := NewProblem(
+		NewCategorizedHerror("get", "not_found", "no such user", nil, map[string]any{"user": "7"}),
+		httptest.NewRequest("GET", "/users/7?x=1", nil),
+		WithProblemTypeBase("https://errors.example.com/"),
+		WithStatusCodes(map[string]int{"NOT_FOUND": 410}),
+	)
+	if p.Status != 410 || p.Type != "https://errors.example.com/not_found" || p.Instance != "/users/7?x=1" ||
+		p.Detail != "no such user" || p.Extensions["user"] != "7" {
+		t.Errorf("customized problem wrong: %+v", p)
+	}
+}
+
+func TestProblemFormatter(t *testing.T) {
+	h, _ := AsHerror(NewCategorizedHerror("op", "conflict", "already exists", errors.New("dup key"), nil))
+	var m map[string]any
+	if err := json.Unmarshal([]byte(ProblemFormatter(h)), &m); err != nil {
+		t.Fatalf("ProblemFormatter produced invalid JSON: %v", err)
+	}
+	if m["status"] != float64(409) || m["detail"] != "already exists" {
+		t.Errorf("ProblemFormatter = %v", m)
+	}
+}
+
+func TestHandleErrors(t *testing.T) {
+	logBuf := &bytes.Buffer{}
+	h := HandleErrors(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Path == "/ok" {
+			w.Write([]byte("fine"))
+			return nil
+		}
+		return NewCategorizedHerror("load", "forbidden", "not allowed", errors.New("acl row 17 missing"), nil)
+	}, WithHTTPWriter(logBuf), WithHTTPFormatter(PlainFormatter))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/ok", nil))
+	if rec.Code != 200 || rec.Body.String() != "fine" || logBuf.Len() != 0 {
+		t.Errorf("successful handler altered: %d %q (log %q)", rec.Code, rec.Body.String(), logBuf.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/secret", nil))
+	if rec.Code != 403 || rec.Header().Get("Content-Type") != ProblemContentType {
+		t.Errorf("response = %d %q; want 403 problem+json", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if strings.Contains(rec.Body.String(), "acl row") {
+		t.Errorf("response leaks the cause: %s", rec.Body.String())
+	}
+	if got := logBuf.String(); got != "load: not allowed\n" {
+		t.Errorf("log = %q; want the formatted error", got)
+	}
+
+	// HandlerFunc is itself an http.Handler with the defaults
+	var fn HandlerFunc = func(http.ResponseWriter, *http.Request) error { return nil }
+	fn.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestHandleErrors_PartialResponse(t *testing.T) {
+	partial := func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("partial"))
+		return NewCategorizedHerror("stream", "validation", "bad", nil, nil)
+	}
+	logBuf := &bytes.Buffer{}
+	reg := NewRegistry()
+	h := HandleErrors(partial, WithHTTPWriter(logBuf), WithHTTPFormatter(PlainFormatter), WithHTTPRegistry(reg))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/stream", nil))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "partial" || rec.Header().Get("Content-Type") == ProblemContentType {
+		t.Errorf("started response altered: %d %q %q", rec.Code, rec.Body.String(), rec.Header().Get("Content-Type"))
+	}
+	if logBuf.String() != "stream: bad\n" || reg.Counts()["validation"] != 1 {
+		t.Errorf("error should still be reported: %q", logBuf.String())
+	}
+
+	defer SetDefault(SetDefault(NewReporter()))
+	defer func(w io.Writer) { handlerFuncConfig.writer = w }(handlerFuncConfig.writer)
+	handlerFuncConfig.writer = nil
+	rec = httptest.NewRecorder()
+	HandlerFunc(partial).ServeHTTP(rec, httptest.NewRequest("GET", "/stream", nil))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "partial" {
+		t.Errorf("HandlerFunc altered the started response: %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleErrors_RegistryFollowsDefault(t *testing.T) {
+	failing := func(http.ResponseWriter, *http.Request) error {
+		return NewCategorizedHerror("op", "conflict", "", nil, nil)
+	}
+	h := HandleErrors(failing, WithHTTPWriter(nil))
+	disabled := HandleErrors(failing, WithHTTPWriter(nil), WithHTTPRegistry(nil))
+
+	r := NewReporter()
+	defer SetDefault(SetDefault(r))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	disabled.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if got := r.Registry().Counts()["conflict"]; got != 1 {
+		t.Errorf("registry of the Reporter set after HandleErrors counted %d; want 1", got)
+	}
+
+	// HandlerFunc shares one default configuration and counts the same way
+	defer func(w io.Writer) { handlerFuncConfig.writer = w }(handlerFuncConfig.writer)
+	handlerFuncConfig.writer = nil
+	rec := httptest.NewRecorder()
+	HandlerFunc(failing).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusConflict || r.Registry().Counts()["conflict"] != 2 {
+		t.Errorf("HandlerFunc = %d, count %d", rec.Code, r.Registry().Counts()["conflict"])
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
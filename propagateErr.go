@@ -6,7 +6,8 @@ package horus
 
 // PropagateErr wraps a non-nil error in an Herror with the given context.
 // If err is already an Herror, its Category and Details are optionally
-// preserved (unless overridden) and merged with the new details.
+// preserved (unless overridden) and merged with the new details, and its
+// Code, Severity and definition are inherited.
 // If err is nil, PropagateErr returns nil.
 func PropagateErr(
 	op, category, message string,
@@ -18,11 +19,16 @@ func PropagateErr(
 	}
 
 	// Determine base Category and Details if err is already an Herror
-	var baseCat string
+	var baseCat, baseCode string
+	var baseSeverity Severity
 	var baseDetails map[string]any
+	var baseDef *Sentinel
 	if herr, ok := AsHerror(err); ok {
 		baseCat = herr.Category
+		baseCode = herr.Code
+		baseSeverity = herr.Severity
 		baseDetails = herr.Details
+		baseDef = herr.def
 	}
 
 	// Override category if provided
@@ -40,7 +46,11 @@ func PropagateErr(
 	}
 
 	// Use the internal constructor so we always get a *Herror with a stack trace
-	return newHerror(op, baseCat, message, err, merged)
+	herr := newHerror(op, baseCat, message, err, merged)
+	herr.Code = baseCode
+	herr.Severity = baseSeverity
+	herr.def = baseDef
+	return herr
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
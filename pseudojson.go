@@ -0,0 +1,352 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// defaultPseudoJSONWidth is the line width used when COLUMNS is not set.
+	defaultPseudoJSONWidth = 80
+	// defaultPseudoJSONMaxLines caps the lines of a single value.
+	defaultPseudoJSONMaxLines = 20
+	// minPseudoJSONWrap keeps values readable when keys leave little room.
+	minPseudoJSONWrap = 20
+)
+
+// PseudoJSONOption customizes NewPseudoJSONFormatter.
+type PseudoJSONOption func(*pseudoJSONConfig)
+
+type pseudoJSONConfig struct {
+	width    int
+	maxLines int
+}
+
+// PseudoJSONWidth wraps values so lines fit in n columns; 0 disables
+// wrapping. The default is $COLUMNS when exported, or 80.
+func PseudoJSONWidth(n int) PseudoJSONOption {
+	return func(cfg *pseudoJSONConfig) {
+		cfg.width = n
+	}
+}
+
+// PseudoJSONMaxLines truncates values longer than n lines, noting how many
+// were left out; 0 disables truncation. The default is 20.
+func PseudoJSONMaxLines(n int) PseudoJSONOption {
+	return func(cfg *pseudoJSONConfig) {
+		cfg.maxLines = n
+	}
+}
+
+func defaultPseudoJSONConfig() pseudoJSONConfig {
+	return pseudoJSONConfig{width: columnsWidth(), maxLines: defaultPseudoJSONMaxLines}
+}
+
+// columnsWidth returns $COLUMNS when it holds a positive number, else 80.
+// The width of the terminal itself is not queried, and shells usually do
+// not export COLUMNS, so pass PseudoJSONWidth to follow a wider terminal.
+func columnsWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return defaultPseudoJSONWidth
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewPseudoJSONFormatter returns a FormatterFunc rendering an Herror as
+// aligned key/value lines followed by its stack, drawn with the package-wide
+// Theme. Only the fields an Herror has are shown:
+//
+//	Op      load,
+//	Message load failed,
+//	Err     operation 'open' failed: cannot open (caused by: EOF) [category: io],
+//	Details
+//	  path  /etc/app.yaml,
+//	  user
+//	    id   7,
+//
+//	Category config,
+//	Chain
+//	  operation 'open' failed: cannot open [category: io]
+//	  EOF
+//	Stack
+//	  main.load() /src/main.go:42
+//
+// Long values wrap at the configured width and multi-line values stay
+// indented under their first line; nested maps and slices in Details are
+// rendered as nested blocks. A branching cause is drawn as a tree, a linear
+// one of more than one layer is also listed under Chain.
+func NewPseudoJSONFormatter(opts ...PseudoJSONOption) FormatterFunc {
+	cfg := defaultPseudoJSONConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(h *Herror) string {
+		p := newPseudoJSON(h, cfg)
+		width := pseudoJSONKeyWidth(h)
+
+		p.row(0, width, "Op", h.Op)
+		p.row(0, width, "Message", h.Message)
+		if h.Err != nil {
+			p.field(0, width, "Err", causeText(h), p.theme.Key)
+		}
+		if details := h.redactedDetails(); len(details) > 0 {
+			p.b.WriteString(paint(p.theme.Key, "Details") + "\n")
+			p.details(width, details)
+			p.b.WriteString("\n")
+		}
+		p.row(0, width, "Category", h.Category)
+		p.row(0, width, "Severity", h.Severity.String())
+		p.row(0, width, "Code", h.Code)
+		p.row(0, width, "Help", h.HelpURL())
+		p.chain(h)
+		p.stack(h)
+		return p.b.String()
+	}
+}
+
+// PseudoJSONFormatter renders an Herror with NewPseudoJSONFormatter's defaults.
+func PseudoJSONFormatter(h *Herror) string {
+	return NewPseudoJSONFormatter()(h)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// causeText renders h's cause as %v prints it, or as a tree (FormatTree) when
+// the chain branches (errors.Join, MultiError).
+func causeText(h *Herror) string {
+	if h.Err != nil && hasBranches(h.Err) {
+		return FormatTree(h.Err)
+	}
+	return fmt.Sprintf("%v", h.Err)
+}
+
+// pseudoJSONKeyWidth returns the width keys are padded to: the widest of the
+// top-level fields h has and of its top-level detail keys.
+func pseudoJSONKeyWidth(h *Herror) int {
+	fields := []struct {
+		key     string
+		present bool
+	}{
+		{"Op", h.Op != ""},
+		{"Message", h.Message != ""},
+		{"Err", h.Err != nil},
+		{"Category", h.Category != ""},
+		{"Severity", h.Severity != SeverityUnset},
+		{"Code", h.Code != ""},
+		{"Help", h.HelpURL() != ""},
+	}
+	var keys []string
+	for _, f := range fields {
+		if f.present {
+			keys = append(keys, f.key)
+		}
+	}
+	for key := range h.redactedDetails() {
+		keys = append(keys, key)
+	}
+	width := 0
+	for _, key := range keys {
+		width = max(width, len(key))
+	}
+	return width
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// pseudoJSON accumulates the rendering of one Herror.
+type pseudoJSON struct {
+	b     strings.Builder
+	cfg   pseudoJSONConfig
+	theme *Theme
+	value Style
+}
+
+func newPseudoJSON(h *Herror, cfg pseudoJSONConfig) *pseudoJSON {
+	theme := GetTheme()
+	return &pseudoJSON{cfg: cfg, theme: theme, value: theme.valueStyle(h)}
+}
+
+// row renders a top-level field unless value is empty.
+func (p *pseudoJSON) row(indent, width int, key, value string) {
+	if value != "" {
+		p.field(indent, width, key, value, p.theme.Key)
+	}
+}
+
+// field renders "key value," with key padded to width. Continuation lines of
+// value are aligned under its first line.
+func (p *pseudoJSON) field(indent, width int, key, value string, keyStyle Style) {
+	column := indent + max(width, len(key)) + 1
+	lines := p.layout(value, column)
+	fmt.Fprintf(&p.b, "%s%s %s", strings.Repeat(" ", indent), paint(keyStyle, fmt.Sprintf("%-*s", width, key)), paint(p.value, lines[0]))
+	for _, line := range lines[1:] {
+		p.b.WriteString("\n" + strings.Repeat(" ", column) + paint(p.value, line))
+	}
+	p.b.WriteString(",\n")
+}
+
+// header renders the key of a nested block.
+func (p *pseudoJSON) header(indent int, key string) {
+	p.b.WriteString(strings.Repeat(" ", indent) + paint(p.theme.DetailKey, key) + "\n")
+}
+
+// item renders one line of a list section, without a trailing comma.
+func (p *pseudoJSON) item(indent int, text string, style Style) {
+	lines := p.layout(text, indent+2)
+	p.b.WriteString(strings.Repeat(" ", indent) + paint(style, lines[0]) + "\n")
+	for _, line := range lines[1:] {
+		p.b.WriteString(strings.Repeat(" ", indent+2) + paint(style, line) + "\n")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// details renders the top-level details, sorted by key and indented by two.
+func (p *pseudoJSON) details(width int, details map[string]any) {
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p.detail(2, width, k, details[k])
+	}
+}
+
+// detail renders one detail. A non-empty map becomes a block of its entries
+// and a non-empty slice a block of "-" items, both indented by two more
+// columns; anything else is a field holding its %v.
+func (p *pseudoJSON) detail(indent, width int, key string, v any) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Len() == 0 {
+			break
+		}
+		entries := make(map[string]any, rv.Len())
+		childWidth := 0
+		for iter := rv.MapRange(); iter.Next(); {
+			k := fmt.Sprint(iter.Key().Interface())
+			entries[k] = iter.Value().Interface()
+			childWidth = max(childWidth, len(k))
+		}
+		keys := make([]string, 0, len(entries))
+		for k := range entries {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		p.header(indent, key)
+		for _, k := range keys {
+			p.detail(indent+2, childWidth, k, entries[k])
+		}
+		return
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 || rv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		p.header(indent, key)
+		for i := 0; i < rv.Len(); i++ {
+			p.detail(indent+2, 1, "-", rv.Index(i).Interface())
+		}
+		return
+	}
+	p.field(indent, width, key, fmt.Sprintf("%v", v), p.theme.DetailKey)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// chain lists the layers below h when the cause is linear and has more than
+// one layer; a single cause is already shown by Err, a branching one as a
+// tree.
+func (p *pseudoJSON) chain(h *Herror) {
+	layers := Chain(h)
+	if len(layers) < 3 || hasBranches(h.Err) {
+		return
+	}
+	p.b.WriteString(paint(p.theme.Key, "Chain") + "\n")
+	for _, layer := range layers[1:] {
+		p.item(2, treeLabel(layer), p.value)
+	}
+}
+
+// stack renders the deepest stack once; outer layers only show their own
+// frames.
+func (p *pseudoJSON) stack(h *Herror) {
+	layers := stackLayers(h)
+	if len(layers) == 0 {
+		return
+	}
+	p.b.WriteString(paint(p.theme.Key, "Stack") + "\n")
+	for _, layer := range layers {
+		if len(layers) > 1 {
+			p.b.WriteString("  " + paint(p.theme.Key, layer.herr.Op) + "\n")
+		}
+		for _, frame := range layer.frames {
+			fn := paint(p.theme.Function, frame.Function+"()")
+			loc := paint(p.theme.Location, fmt.Sprintf(" %s:%d", frame.File, frame.Line))
+			p.b.WriteString("  " + fn + loc + "\n")
+		}
+		if layer.shared > 0 {
+			p.b.WriteString("  " + paint(p.theme.Location, fmt.Sprintf("... %d frames in common with the layer below", layer.shared)) + "\n")
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// layout splits value into the lines shown from column on: each line is
+// wrapped to the configured width, and lines past the maximum are replaced
+// by a note counting them.
+func (p *pseudoJSON) layout(value string, column int) []string {
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		lines = append(lines, wrapLine(line, p.cfg.width-column, p.cfg.width > 0)...)
+	}
+	if p.cfg.maxLines > 0 && len(lines) > p.cfg.maxLines {
+		note := fmt.Sprintf("... %d more lines", len(lines)-p.cfg.maxLines)
+		if len(lines)-p.cfg.maxLines == 1 {
+			note = "... 1 more line"
+		}
+		lines = append(lines[:p.cfg.maxLines], note)
+	}
+	return lines
+}
+
+// wrapLine breaks line into pieces of at most avail runes (never fewer than
+// minPseudoJSONWrap), at the last space when there is one.
+func wrapLine(line string, avail int, wrap bool) []string {
+	if !wrap {
+		return []string{line}
+	}
+	avail = max(avail, minPseudoJSONWrap)
+	var lines []string
+	for utf8.RuneCountInString(line) > avail {
+		runes := []rune(line)
+		cut := avail
+		next := avail
+		for i := avail; i > 0; i-- {
+			if runes[i] == ' ' {
+				cut, next = i, i+1
+				break
+			}
+		}
+		lines = append(lines, string(runes[:cut]))
+		line = string(runes[next:])
+	}
+	return append(lines, line)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,104 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestPseudoJSONFormatter_OnlyPresentFields(t *testing.T) {
+	defer SetColorMode(SetColorMode(ColorNever))
+	if got := PseudoJSONFormatter(&Herror{Op: "op", Message: "msg"}); got != "Op      op,\nMessage msg,\n" {
+		t.Errorf("minimal Herror =\n%q", got)
+	}
+	if got := PseudoJSONFormatter(&Herror{}); got != "" {
+		t.Errorf("empty Herror = %q; want nothing", got)
+	}
+	got := PseudoJSONFormatter(&Herror{Op: "op", Code: "X"})
+	if strings.Contains(got, "<nil>") || strings.Contains(got, "Details") || got != "Op   op,\nCode X,\n" {
+		t.Errorf("absent fields should be left out:\n%q", got)
+	}
+}
+
+func TestPseudoJSONFormatter_WrapAndTruncate(t *testing.T) {
+	defer SetColorMode(SetColorMode(ColorNever))
+	h := &Herror{
+		Op:      "op",
+		Message: "the quick brown fox jumps over the lazy dog",
+		Details: map[string]any{"log": "l1\nl2\nl3\nl4"},
+	}
+
+	got := NewPseudoJSONFormatter(PseudoJSONWidth(30), PseudoJSONMaxLines(3))(h)
+	want := "Op      op,\n" +
+		"Message the quick brown fox\n" +
+		"        jumps over the lazy\n" +
+		"        dog,\n" +
+		"Details\n" +
+		"  log     l1\n" +
+		"          l2\n" +
+		"          l3\n" +
+		"          ... 1 more line,\n\n"
+	if got != want {
+		t.Errorf("wrapped output =\n%s\nwant\n%s", got, want)
+	}
+
+	got = NewPseudoJSONFormatter(PseudoJSONWidth(0), PseudoJSONMaxLines(0))(h)
+	if !strings.Contains(got, "Message the quick brown fox jumps over the lazy dog,\n") || !strings.Contains(got, "l4,") {
+		t.Errorf("width 0 and max lines 0 should keep values whole:\n%s", got)
+	}
+
+	long := &Herror{Op: strings.Repeat("x", 50)}
+	if got := NewPseudoJSONFormatter(PseudoJSONWidth(30))(long); got != "Op "+strings.Repeat("x", 27)+"\n   "+strings.Repeat("x", 23)+",\n" {
+		t.Errorf("words longer than the line should be broken:\n%s", got)
+	}
+}
+
+func TestPseudoJSONFormatter_NestedDetails(t *testing.T) {
+	defer SetColorMode(SetColorMode(ColorNever))
+	h := &Herror{Op: "op", Details: map[string]any{
+		"user":  map[string]any{"name": "ada", "id": 7},
+		"tags":  []string{"a", "b"},
+		"empty": []int{},
+		"rows":  []any{map[string]int{"n": 1}},
+	}}
+
+	got := NewPseudoJSONFormatter(PseudoJSONWidth(0))(h)
+	want := "Op    op,\n" +
+		"Details\n" +
+		"  empty []," +
+		"\n  rows\n" +
+		"    -\n" +
+		"      n 1,\n" +
+		"  tags\n" +
+		"    - a,\n" +
+		"    - b,\n" +
+		"  user\n" +
+		"    id   7,\n" +
+		"    name ada,\n\n"
+	if got != want {
+		t.Errorf("nested details =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPseudoJSONFormatter_Chain(t *testing.T) {
+	defer SetColorMode(SetColorMode(ColorNever))
+	t.Setenv("COLUMNS", "")
+	inner := NewCategorizedHerror("open", "io", "cannot open", errors.New("eof"), nil)
+	h, _ := AsHerror(Wrap(inner, "load", "load failed"))
+
+	got := PseudoJSONFormatter(h)
+	if !strings.Contains(got, "Chain\n  operation 'open' failed: cannot open [category: io]\n  eof\nStack\n") {
+		t.Errorf("wrapped chain missing:\n%s", got)
+	}
+	if strings.Contains(PseudoJSONFormatter(inner.(*Herror)), "\nChain\n") {
+		t.Error("a single cause is already shown by Err")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
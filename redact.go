@@ -0,0 +1,238 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DefaultRedactionMask replaces redacted values when no mask is configured.
+const DefaultRedactionMask = "[REDACTED]"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Secret wraps a value that must never be printed. Every rendering path
+// (fmt verbs, JSON, slog, every horus formatter) shows the redaction mask
+// instead; only Reveal returns the value.
+//
+//	horus.WithDetail(err, "dsn", horus.NewSecret(dsn))
+type Secret[T any] struct {
+	value T
+}
+
+// NewSecret wraps v in a Secret.
+func NewSecret[T any](v T) Secret[T] {
+	return Secret[T]{value: v}
+}
+
+// Reveal returns the wrapped value.
+func (s Secret[T]) Reveal() T { return s.value }
+
+// String returns the redaction mask.
+func (s Secret[T]) String() string { return DefaultRedactionMask }
+
+// GoString returns the redaction mask, so %#v does not leak the value either.
+func (s Secret[T]) GoString() string { return DefaultRedactionMask }
+
+// Format prints the redaction mask for every verb.
+func (s Secret[T]) Format(f fmt.State, verb rune) { fmt.Fprint(f, DefaultRedactionMask) }
+
+// MarshalJSON encodes the redaction mask.
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + DefaultRedactionMask + `"`), nil
+}
+
+// LogValue implements slog.LogValuer with the redaction mask.
+func (s Secret[T]) LogValue() slog.Value { return slog.StringValue(DefaultRedactionMask) }
+
+func (s Secret[T]) secret() {}
+
+// secretValue is implemented by every Secret instantiation.
+type secretValue interface{ secret() }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ValueMatcher reports whether a detail value must be redacted regardless of
+// its key.
+type ValueMatcher func(v any) bool
+
+// MatchPattern returns a ValueMatcher that redacts values whose %v rendering
+// matches the regular expression, e.g. `^Bearer\s` or a JWT shape.
+func MatchPattern(expr string) ValueMatcher {
+	re := regexp.MustCompile(expr)
+	return func(v any) bool {
+		return re.MatchString(fmt.Sprintf("%v", v))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Redactor decides which detail values are masked before an Herror is
+// rendered. The package-wide Redactor (see SetRedactor) is applied by
+// Error, %v/%+v, MarshalJSON, LogValue, every formatter and problem details,
+// so all output paths follow the same policy. Secret values are always masked.
+type Redactor struct {
+	keys   []string
+	values []ValueMatcher
+	mask   string
+}
+
+// RedactOption customizes a Redactor.
+type RedactOption func(*Redactor)
+
+// RedactKeys masks details whose key contains one of patterns,
+// case-insensitively ("token" matches "refresh_token" and "X-Token").
+func RedactKeys(patterns ...string) RedactOption {
+	return func(r *Redactor) {
+		for _, p := range patterns {
+			r.keys = append(r.keys, strings.ToLower(p))
+		}
+	}
+}
+
+// RedactValues masks details whose value satisfies one of matchers.
+func RedactValues(matchers ...ValueMatcher) RedactOption {
+	return func(r *Redactor) {
+		r.values = append(r.values, matchers...)
+	}
+}
+
+// RedactMask sets the replacement text (defaults to DefaultRedactionMask).
+func RedactMask(mask string) RedactOption {
+	return func(r *Redactor) {
+		r.mask = mask
+	}
+}
+
+// NewRedactor returns a Redactor configured by opts. With no options it only
+// masks Secret values.
+func NewRedactor(opts ...RedactOption) *Redactor {
+	r := &Redactor{mask: DefaultRedactionMask}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// DefaultRedactor masks the usual credential keys (password, token, secret,
+// api_key, authorization, cookie and their variants) and bearer tokens.
+func DefaultRedactor() *Redactor {
+	return NewRedactor(
+		RedactKeys("password", "passwd", "token", "secret", "api_key", "apikey", "authorization", "cookie"),
+		RedactValues(MatchPattern(`(?i)^bearer\s`)),
+	)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var redactor atomic.Pointer[Redactor]
+
+func init() {
+	redactor.Store(DefaultRedactor())
+}
+
+// SetRedactor replaces the package-wide Redactor and returns the previous
+// one. A nil Redactor turns off key and value matching; Secret values are
+// still masked.
+func SetRedactor(r *Redactor) *Redactor {
+	return redactor.Swap(r)
+}
+
+// GetRedactor returns the package-wide Redactor.
+func GetRedactor() *Redactor {
+	return redactor.Load()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Redact returns a copy of details with every sensitive value replaced by the
+// mask. Nested maps of any key and value type and slices are redacted
+// recursively, keys and leaves alike; redacted maps become map[string]any and
+// slices []any. details itself is never modified.
+func (r *Redactor) Redact(details map[string]any) map[string]any {
+	if details == nil {
+		return nil
+	}
+	out := make(map[string]any, len(details))
+	for k, v := range details {
+		out[k] = r.redactValue(k, v)
+	}
+	return out
+}
+
+func (r *Redactor) redactValue(key string, v any) any {
+	if _, ok := v.(secretValue); ok {
+		return r.maskText()
+	}
+	if r.matchKey(key) {
+		return r.maskText()
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.IsNil() {
+			break
+		}
+		out := make(map[string]any, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			k := fmt.Sprint(iter.Key().Interface())
+			out[k] = r.redactValue(k, iter.Value().Interface())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() || rv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		out := make([]any, rv.Len())
+		for i := range out {
+			out[i] = r.redactValue("", rv.Index(i).Interface())
+		}
+		return out
+	}
+	if r == nil {
+		return v
+	}
+	for _, match := range r.values {
+		if match(v) {
+			return r.maskText()
+		}
+	}
+	return v
+}
+
+func (r *Redactor) matchKey(key string) bool {
+	if r == nil {
+		return false
+	}
+	lower := strings.ToLower(key)
+	for _, p := range r.keys {
+		if strings.Contains(lower, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) maskText() string {
+	if r == nil || r.mask == "" {
+		return DefaultRedactionMask
+	}
+	return r.mask
+}
+
+// redactedDetails returns h's details as they may be shown, under the
+// package-wide Redactor.
+func (h *Herror) redactedDetails() map[string]any {
+	return GetRedactor().Redact(h.Details)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
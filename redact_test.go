@@ -0,0 +1,145 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestSecret(t *testing.T) {
+	s := NewSecret("hunter2")
+	if s.Reveal() != "hunter2" {
+		t.Errorf("Reveal = %q", s.Reveal())
+	}
+	for _, out := range []string{
+		fmt.Sprint(s), fmt.Sprintf("%v %+v %#v %s %q", s, s, s, s, s),
+		fmt.Sprintf("%v", map[string]any{"k": s}),
+	} {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("secret leaked: %s", out)
+		}
+	}
+	raw, _ := json.Marshal(map[string]any{"k": s})
+	if string(raw) != `{"k":"[REDACTED]"}` {
+		t.Errorf("json = %s", raw)
+	}
+}
+
+func TestRedactor_Redact(t *testing.T) {
+	r := NewRedactor(RedactKeys("PIN"), RedactValues(MatchPattern(`^\d{4}-\d{4}$`)), RedactMask("***"))
+	in := map[string]any{
+		"user_pin": 1234,
+		"card":     "1111-2222",
+		"name":     "ada",
+		"dsn":      NewSecret("postgres://u:p@h"),
+		"nested":   map[string]any{"pin": 9, "ok": true},
+	}
+	got := r.Redact(in)
+	nested := got["nested"].(map[string]any)
+	if got["user_pin"] != "***" || got["card"] != "***" || got["dsn"] != "***" || got["name"] != "ada" ||
+		nested["pin"] != "***" || nested["ok"] != true {
+		t.Errorf("Redact = %v", got)
+	}
+	if in["user_pin"] != 1234 {
+		t.Errorf("input map was modified: %v", in)
+	}
+
+	// a nil Redactor still masks secrets
+	var none *Redactor
+	got = none.Redact(map[string]any{"password": "x", "dsn": NewSecret(1)})
+	if got["password"] != "x" || got["dsn"] != DefaultRedactionMask {
+		t.Errorf("nil Redact = %v", got)
+	}
+}
+
+func TestRedaction_NestedTypedMapsAndSlices(t *testing.T) {
+	err := NewHerror("login", "denied", nil, map[string]any{
+		"creds":  []any{map[string]any{"password": "hunter2", "user": "ada"}},
+		"hdrs":   map[string]string{"Authorization": "Bearer abc", "Accept": "json"},
+		"values": [2]string{"bearer xyz", "plain"},
+		"deep":   map[int][]map[string]Secret[string]{1: {{"dsn": NewSecret("s3cr3t")}}},
+	})
+	h, _ := AsHerror(err)
+
+	got := GetRedactor().Redact(h.Details)
+	creds := got["creds"].([]any)[0].(map[string]any)
+	hdrs := got["hdrs"].(map[string]any)
+	values := got["values"].([]any)
+	if creds["password"] != DefaultRedactionMask || creds["user"] != "ada" ||
+		hdrs["Authorization"] != DefaultRedactionMask || hdrs["Accept"] != "json" ||
+		values[0] != DefaultRedactionMask || values[1] != "plain" {
+		t.Errorf("Redact = %v", got)
+	}
+
+	var logBuf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&logBuf, nil)).Error("x", "err", h)
+	raw, _ := json.Marshal(h)
+	for name, out := range map[string]string{
+		"Error":      h.Error(),
+		"JSON":       string(raw),
+		"PseudoJSON": PseudoJSONFormatter(h),
+		"Logfmt":     LogfmtFormatter(h),
+		"slog":       logBuf.String(),
+	} {
+		for _, leak := range []string{"hunter2", "abc", "xyz", "s3cr3t"} {
+			if strings.Contains(out, leak) {
+				t.Errorf("%s leaks %q: %s", name, leak, out)
+			}
+		}
+	}
+}
+
+func TestRedaction_AllOutputs(t *testing.T) {
+	err := NewCategorizedHerror("login", "auth", "denied", nil, map[string]any{
+		"user":          "ada",
+		"password":      "hunter2",
+		"Authorization": "Bearer abc.def",
+		"header":        "bearer abc.def",
+		"session":       NewSecret("s3cr3t"),
+	})
+	h, _ := AsHerror(err)
+
+	var logBuf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&logBuf, nil)).Error("x", "err", h)
+	raw, _ := json.Marshal(h)
+	outputs := map[string]string{
+		"Error":       h.Error(),
+		"%v":          fmt.Sprintf("%v", h),
+		"%+v":         fmt.Sprintf("%+v", h),
+		"JSON":        string(raw),
+		"JSONFmt":     JSONFormatter(h),
+		"PseudoJSON":  PseudoJSONFormatter(h),
+		"SimpleColor": SimpleColoredFormatter(h),
+		"Problem":     ProblemFormatter(h),
+		"slog":        logBuf.String(),
+	}
+	for name, out := range outputs {
+		for _, leak := range []string{"hunter2", "abc.def", "s3cr3t"} {
+			if strings.Contains(out, leak) {
+				t.Errorf("%s leaks %q: %s", name, leak, out)
+			}
+		}
+		if !strings.Contains(out, "ada") {
+			t.Errorf("%s dropped a safe detail: %s", name, out)
+		}
+	}
+	if h.Details["password"] != "hunter2" {
+		t.Errorf("Details must keep the raw value for programmatic access")
+	}
+
+	defer SetRedactor(SetRedactor(nil))
+	if !strings.Contains(h.Error(), "hunter2") || strings.Contains(h.Error(), "s3cr3t") {
+		t.Errorf("with a nil Redactor only secrets should be masked: %s", h.Error())
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,162 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RegistryEntry holds how many times a key has been seen and when.
+type RegistryEntry struct {
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// RegistrySnapshot is a point-in-time copy of a Registry. Mutating it never
+// affects the Registry it was taken from.
+type RegistrySnapshot struct {
+	Total      RegistryEntry            // every registered error
+	ByCategory map[string]RegistryEntry // keyed by Herror.Category ("unknown" if none)
+	ByOp       map[string]RegistryEntry // keyed by the outermost Herror.Op ("unknown" if none)
+	ByCause    map[string]RegistryEntry // keyed by the Go type of the root cause (e.g. "syscall.Errno")
+	BySeverity map[string]RegistryEntry // keyed by SeverityOf(err) ("unknown" if unset)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Registry counts registered errors by category, operation, root-cause type
+// and severity.
+// It is safe for concurrent use.
+type Registry struct {
+	mu         sync.Mutex
+	now        func() time.Time
+	total      RegistryEntry
+	byCategory map[string]*RegistryEntry
+	byOp       map[string]*RegistryEntry
+	byCause    map[string]*RegistryEntry
+	bySeverity map[string]*RegistryEntry
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	r := &Registry{now: time.Now}
+	r.reset()
+	return r
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Register records err. Nil errors, and calls on a nil Registry, are ignored.
+func (r *Registry) Register(err error) {
+	if r == nil || err == nil {
+		return
+	}
+
+	category, op := "unknown", "unknown"
+	if herr, ok := AsHerror(err); ok {
+		if herr.Category != "" {
+			category = herr.Category
+		}
+		if herr.Op != "" {
+			op = herr.Op
+		}
+	}
+	cause := fmt.Sprintf("%T", RootCause(err))
+	severity := "unknown"
+	if s := SeverityOf(err); s != SeverityUnset {
+		severity = s.String()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.total.see(now)
+	seeKey(r.byCategory, category, now)
+	seeKey(r.byOp, op, now)
+	seeKey(r.byCause, cause, now)
+	seeKey(r.bySeverity, severity, now)
+}
+
+// Counts returns a copy of the per-category counts.
+func (r *Registry) Counts() map[string]int {
+	if r == nil {
+		return map[string]int{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[string]int, len(r.byCategory))
+	for k, e := range r.byCategory {
+		counts[k] = e.Count
+	}
+	return counts
+}
+
+// Snapshot returns a point-in-time copy of every breakdown.
+func (r *Registry) Snapshot() RegistrySnapshot {
+	if r == nil {
+		return RegistrySnapshot{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return RegistrySnapshot{
+		Total:      r.total,
+		ByCategory: copyEntries(r.byCategory),
+		ByOp:       copyEntries(r.byOp),
+		ByCause:    copyEntries(r.byCause),
+		BySeverity: copyEntries(r.bySeverity),
+	}
+}
+
+// Reset clears all counts and timestamps.
+func (r *Registry) Reset() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reset()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *Registry) reset() {
+	r.total = RegistryEntry{}
+	r.byCategory = make(map[string]*RegistryEntry)
+	r.byOp = make(map[string]*RegistryEntry)
+	r.byCause = make(map[string]*RegistryEntry)
+	r.bySeverity = make(map[string]*RegistryEntry)
+}
+
+func (e *RegistryEntry) see(now time.Time) {
+	if e.Count == 0 {
+		e.FirstSeen = now
+	}
+	e.Count++
+	e.LastSeen = now
+}
+
+func seeKey(m map[string]*RegistryEntry, key string, now time.Time) {
+	e, ok := m[key]
+	if !ok {
+		e = &RegistryEntry{}
+		m[key] = e
+	}
+	e.see(now)
+}
+
+func copyEntries(m map[string]*RegistryEntry) map[string]RegistryEntry {
+	out := make(map[string]RegistryEntry, len(m))
+	for k, e := range m {
+		out[k] = *e
+	}
+	return out
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
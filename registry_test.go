@@ -0,0 +1,118 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestRegistry_Breakdowns(t *testing.T) {
+	reg := NewRegistry()
+
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	reg.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	pathErr := &os.PathError{Op: "open", Path: "/x", Err: errors.New("nope")}
+	reg.Register(nil)
+	reg.Register(errors.New("plain"))
+	reg.Register(NewCategorizedHerror("read file", "io", "", pathErr, nil))
+	reg.Register(PropagateErr("load config", "", "", NewCategorizedHerror("read file", "io", "", pathErr, nil), nil))
+
+	snap := reg.Snapshot()
+
+	if snap.Total.Count != 3 {
+		t.Errorf("Total.Count = %d; want 3", snap.Total.Count)
+	}
+	if got := snap.ByCategory["io"].Count; got != 2 {
+		t.Errorf("ByCategory[io] = %d; want 2", got)
+	}
+	if got := snap.ByCategory["unknown"].Count; got != 1 {
+		t.Errorf("ByCategory[unknown] = %d; want 1", got)
+	}
+	if snap.ByOp["read file"].Count != 1 || snap.ByOp["load config"].Count != 1 || snap.ByOp["unknown"].Count != 1 {
+		t.Errorf("ByOp = %v", snap.ByOp)
+	}
+	if got := snap.ByCause["*errors.errorString"].Count; got != 3 {
+		t.Errorf("ByCause[*errors.errorString] = %d; want 3 (got %v)", got, snap.ByCause)
+	}
+
+	io := snap.ByCategory["io"]
+	if !io.FirstSeen.Before(io.LastSeen) {
+		t.Errorf("io FirstSeen %v should be before LastSeen %v", io.FirstSeen, io.LastSeen)
+	}
+	if !snap.Total.FirstSeen.Equal(clock.Add(-2*time.Second)) || !snap.Total.LastSeen.Equal(clock) {
+		t.Errorf("Total timestamps = %v..%v", snap.Total.FirstSeen, snap.Total.LastSeen)
+	}
+
+	// snapshots are copies
+	snap.ByCategory["io"] = RegistryEntry{Count: 99}
+	if got := reg.Counts()["io"]; got != 2 {
+		t.Errorf("snapshot mutation leaked into registry: io = %d", got)
+	}
+}
+
+func TestRegistry_Reset(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(errors.New("x"))
+	reg.Reset()
+
+	snap := reg.Snapshot()
+	if snap.Total.Count != 0 || len(snap.ByCategory) != 0 || len(snap.ByOp) != 0 || len(snap.ByCause) != 0 {
+		t.Errorf("Reset left data behind: %+v", snap)
+	}
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	reg := NewRegistry()
+	err := NewCategorizedHerror("op", "cat", "", nil, nil)
+
+	const workers, perWorker = 16, 100
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				reg.Register(err)
+				_ = reg.Counts()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := reg.Counts()["cat"]; got != workers*perWorker {
+		t.Errorf("count = %d; want %d", got, workers*perWorker)
+	}
+}
+
+func TestRegistry_ByCauseOSError(t *testing.T) {
+	reg := NewRegistry()
+	_, err := os.Open("/nonexistent/horus")
+	reg.Register(NewHerror("open", "", err, nil))
+	if got := reg.Snapshot().ByCause["syscall.Errno"].Count; got != 1 {
+		t.Errorf("an os error should be keyed by its errno, got %v", reg.Snapshot().ByCause)
+	}
+}
+
+func TestRegistry_NilIsNoop(t *testing.T) {
+	var reg *Registry
+	reg.Register(errors.New("ignored"))
+	reg.Reset()
+	NewReporter(WithRegistry(nil)).Registry().Reset()
+	if len(reg.Counts()) != 0 {
+		t.Error("a nil registry counts nothing")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,269 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Reporter owns everything CheckErr needs to report an error: the writer,
+// the formatter, the exit policy, the default op/category/message/details and
+// the registry that counts errors by category. Independent Reporters let two
+// components in one binary (or two parallel tests) use different policies.
+//
+// The package-level CheckErr, CheckEmpty, Must, Panic, RegisterError and
+// GetErrorRegistry delegate to the default Reporter (see Default, SetDefault).
+type Reporter struct {
+	defaults checkParams
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewReporter creates a Reporter whose defaults are the CheckErr defaults
+// overridden by opts (WithWriter, WithFormatter, WithExitFunc, WithOp, ...).
+// Options passed later to a single CheckErr call override these in turn.
+func NewReporter(opts ...checkOpt) *Reporter {
+	cfg := defaultCheckParams()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Reporter{defaults: cfg}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var defaultReporter atomic.Pointer[Reporter]
+
+func init() {
+	defaultReporter.Store(NewReporter())
+}
+
+// Default returns the Reporter used by the package-level functions.
+func Default() *Reporter {
+	return defaultReporter.Load()
+}
+
+// SetDefault replaces the Reporter used by the package-level functions and
+// returns the previous one, so it can be restored:
+//
+//	defer horus.SetDefault(horus.SetDefault(r))
+func SetDefault(r *Reporter) *Reporter {
+	if r == nil {
+		r = NewReporter()
+	}
+	return defaultReporter.Swap(r)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Registry returns the registry this Reporter counts errors into.
+func (r *Reporter) Registry() *Registry {
+	return r.defaults.registry
+}
+
+// RegisterError records err in the Reporter's registry.
+func (r *Reporter) RegisterError(err error) {
+	r.defaults.registry.Register(err)
+}
+
+// ErrorRegistry returns a copy of the current per-category error counts.
+func (r *Reporter) ErrorRegistry() map[string]int {
+	return r.defaults.registry.Counts()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CheckEmpty will call CheckErr if the given string is empty.
+func (r *Reporter) CheckEmpty(val, errMsg string, opts ...checkOpt) {
+	if val == "" {
+		r.CheckErr(
+			errors.New(errMsg),
+			opts...,
+		)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CheckErr registers, wraps, formats and logs a fatal error.
+// If err is non-nil (an empty MultiError counts as nil) it prints using the configured FormatterFunc, then
+// applies the exit policy (os.Exit by default) if the error's severity
+// reaches the exit threshold (see WithExitThreshold).
+func (r *Reporter) CheckErr(err error, opts ...checkOpt) {
+	if noError(err) {
+		return
+	}
+	cfg := r.params(opts)
+	herr := r.report(err, cfg)
+	cfg.finish(herr)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Report runs the same pipeline as CheckErr, then applies ReturnPolicy unless
+// a WithExitPolicy option says otherwise, so by default it hands back the
+// reported *Herror instead of terminating. It returns nil when err is nil or
+// an empty MultiError.
+func (r *Reporter) Report(err error, opts ...checkOpt) error {
+	if noError(err) {
+		return nil
+	}
+	opts = append([]checkOpt{WithExitPolicy(ReturnPolicy)}, opts...)
+	cfg := r.params(opts)
+	herr := r.report(err, cfg)
+	return cfg.finish(herr)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Warn reports err with the CheckErr formatting and registry pipeline at
+// warning severity. It never applies the exit policy. A Reporter without its
+// own WithMessage or WithDetails defaults uses a warning message and details.
+func (r *Reporter) Warn(err error, opts ...checkOpt) {
+	if noError(err) {
+		return
+	}
+	warn := []checkOpt{WithSeverity(SeverityWarning)}
+	if !r.defaults.msgSet {
+		warn = append(warn, WithMessage("A warning was raised during execution"))
+	}
+	if !r.defaults.detSet {
+		warn = append(warn, WithDetails(map[string]any{"location": "warn"}))
+	}
+	opts = append(warn, opts...)
+	r.report(err, r.params(opts))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// params copies the Reporter defaults and applies per-call overrides, so
+// per-call options never leak back into the Reporter.
+func (r *Reporter) params(opts []checkOpt) checkParams {
+	cfg := r.defaults
+	cfg.details = cloneDetails(r.defaults.details)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// report registers, wraps, formats and prints err; it is the pipeline shared
+// by CheckErr, Report and Warn. The members of a MultiError (see
+// reportMembers) are registered and printed one by one; members that are not
+// Herrors get the call's op, category, message and details.
+func (r *Reporter) report(err error, cfg checkParams) *Herror {
+	members, split := reportMembers(err)
+
+	// 1) metrics / instrumentation
+	for _, member := range members {
+		cfg.registry.Register(member)
+	}
+
+	// 2) build a rich *Herror
+	herr := buildHerror(
+		cfg.stackConfig(),
+		1,
+		cfg.op,
+		cfg.category,
+		cfg.message,
+		err,
+		cfg.details,
+	)
+	herr.Severity = cfg.resolveSeverity(err)
+
+	// 3) format & print, without colors unless the writer is a terminal
+	w := NewColorWriter(cfg.writer)
+	if !split {
+		fmt.Fprintln(w, cfg.formatter(herr))
+		return herr
+	}
+	for _, member := range members {
+		mh, ok := AsHerror(member)
+		if !ok {
+			wrapped := *herr
+			wrapped.Err = member
+			mh = &wrapped
+		}
+		fmt.Fprintln(w, cfg.formatter(mh))
+	}
+	return herr
+}
+
+// reportMembers returns the errors report renders one by one, and whether
+// there are several: the members of err when it is a MultiError, or when it
+// is an Herror directly wrapping a non-empty MultiError, its members each
+// wrapped in a copy of that Herror so they keep its context. Any other error,
+// including one with a MultiError deeper in its chain, is rendered whole.
+func reportMembers(err error) ([]error, bool) {
+	switch e := err.(type) {
+	case *MultiError:
+		return e.Errors(), true
+	case *Herror:
+		if multi, ok := e.Err.(*MultiError); ok && multi != nil && multi.Len() > 0 {
+			members := multi.Errors()
+			for i, member := range members {
+				layer := *e
+				layer.Err = member
+				members[i] = &layer
+			}
+			return members, true
+		}
+	}
+	return []error{err}, false
+}
+
+// noError reports whether err holds nothing to report: nil, or a MultiError
+// without members.
+func noError(err error) bool {
+	if multi, ok := err.(*MultiError); ok {
+		return multi == nil || multi.Len() == 0
+	}
+	return err == nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Panic prints a colored panic banner to the Reporter's writer, then panics
+// with an *Herror carrying the operation, message and the caller's stack.
+func (r *Reporter) Panic(op, message string) {
+	r.panicAt(1, op, message)
+}
+
+// panicAt implements Panic for the method and the package-level function.
+// skip is the number of frames between panicAt and the user's call site.
+func (r *Reporter) panicAt(skip int, op, message string) {
+	fmt.Fprintln(NewColorWriter(r.defaults.writer), FormatPanic(op, message))
+	panic(buildHerror(r.defaults.stackConfig(), skip+1, op, PanicCategory, message, nil, nil))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MustWith returns v if err is nil; otherwise it calls r.CheckErr(err).
+// It is the Reporter counterpart of Must (Go methods cannot take type
+// parameters, hence the function form).
+func MustWith[T any](r *Reporter, v T, err error) T {
+	r.CheckErr(err)
+	return v
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// cloneDetails returns a shallow copy of details, or nil if it is nil.
+func cloneDetails(details map[string]any) map[string]any {
+	if details == nil {
+		return nil
+	}
+	copy := make(map[string]any, len(details))
+	for k, v := range details {
+		copy[k] = v
+	}
+	return copy
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,111 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestReporter_IndependentPolicies(t *testing.T) {
+	t.Parallel()
+
+	var codeA, codeB int
+	bufA, bufB := &bytes.Buffer{}, &bytes.Buffer{}
+
+	a := NewReporter(
+		WithWriter(bufA),
+		WithExitFunc(func(c int) { codeA = c }),
+		WithFormatter(PlainFormatter),
+		WithOp("component A"),
+	)
+	b := NewReporter(
+		WithWriter(bufB),
+		WithExitFunc(func(c int) { codeB = c }),
+		WithExitCode(7),
+	)
+
+	a.CheckErr(errors.New("boom"))
+	b.CheckErr(NewCategorizedHerror("op", "io", "msg", nil, nil))
+
+	if codeA != 1 || codeB != 7 {
+		t.Errorf("exit codes = %d, %d; want 1, 7", codeA, codeB)
+	}
+	if got := bufA.String(); got != "component A: An error occurred during execution\n" {
+		t.Errorf("reporter A output = %q", got)
+	}
+	if !strings.Contains(stripANSI(bufB.String()), "check error") {
+		t.Errorf("reporter B output missing default op: %q", bufB.String())
+	}
+
+	// registries are not shared
+	if got := a.ErrorRegistry(); got["unknown"] != 1 || got["io"] != 0 {
+		t.Errorf("reporter A registry = %v", got)
+	}
+	if got := b.ErrorRegistry(); got["io"] != 1 || got["unknown"] != 0 {
+		t.Errorf("reporter B registry = %v", got)
+	}
+}
+
+func TestReporter_CallOptionsDoNotLeak(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	r := NewReporter(WithWriter(buf), WithExitFunc(func(int) {}), WithFormatter(PlainFormatter))
+
+	r.CheckErr(errors.New("x"), WithOp("once"), WithDetails(map[string]any{"k": "v"}))
+	r.CheckErr(errors.New("y"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "once:") || !strings.HasPrefix(lines[1], "check error:") {
+		t.Errorf("per-call options leaked into reporter defaults: %q", lines)
+	}
+	if _, ok := r.defaults.details["k"]; ok {
+		t.Error("per-call details leaked into reporter defaults")
+	}
+}
+
+func TestReporter_CheckEmptyAndMustWith(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	r := NewReporter(WithWriter(&bytes.Buffer{}), WithExitFunc(func(int) { calls++ }))
+
+	r.CheckEmpty("set", "unused")
+	if calls != 0 {
+		t.Fatalf("CheckEmpty exited on a non-empty value")
+	}
+	r.CheckEmpty("", "`--script` is required")
+	if calls != 1 {
+		t.Fatalf("CheckEmpty did not exit on an empty value")
+	}
+
+	if got := MustWith(r, 5, nil); got != 5 || calls != 1 {
+		t.Errorf("MustWith(5, nil) = %d (exits %d); want 5 (exits 1)", got, calls)
+	}
+	MustWith(r, 0, errors.New("bad"))
+	if calls != 2 {
+		t.Errorf("MustWith with error did not exit")
+	}
+}
+
+func TestSetDefault_ReturnsPrevious(t *testing.T) {
+	r := NewReporter()
+	prev := SetDefault(r)
+	defer SetDefault(prev)
+
+	if Default() != r {
+		t.Error("Default() did not return the reporter just set")
+	}
+	if got := SetDefault(prev); got != r {
+		t.Error("SetDefault did not return the previous reporter")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
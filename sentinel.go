@@ -0,0 +1,131 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Sentinel is an error declared once and instantiated many times. Every
+// instance is an *Herror carrying the Sentinel's Code, so errors.Is matches it
+// however it was wrapped:
+//
+//	var ErrNotFound = horus.Define("NOT_FOUND", "resource missing")
+//
+//	return ErrNotFound.New("load user", map[string]any{"id": id})
+//	...
+//	if errors.Is(err, ErrNotFound) { ... }
+//
+// Sentinels declared as struct literals can also carry an exit code, an HTTP
+// status, a message template and a help URL; see Catalog.
+type Sentinel struct {
+	Code       string   // stable machine-readable code, e.g. "NOT_FOUND"
+	Category   string   // category given to instances; empty for none
+	Message    string   // message given to instances
+	ExitCode   int      // exit code used by CheckErr for instances; 0 defers to the ExitCodeTable
+	HTTPStatus int      // status used by the HTTP responder for instances; 0 defers to the category
+	Template   string   // message template with {name} placeholders filled from Details
+	HelpURL    string   // documentation link shown by formatters and used as the problem type
+	Severity   Severity // severity given to instances
+}
+
+// Define declares a Sentinel without a category.
+func Define(code, message string) *Sentinel {
+	return &Sentinel{Code: code, Message: message}
+}
+
+// DefineCategorized declares a Sentinel whose instances have a category.
+func DefineCategorized(code, category, message string) *Sentinel {
+	return &Sentinel{Code: code, Category: category, Message: message}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Error returns "CODE: message".
+func (s *Sentinel) Error() string {
+	return s.Code + ": " + s.Message
+}
+
+// New creates an instance for op with per-call details, capturing the stack.
+func (s *Sentinel) New(op string, details map[string]any) error {
+	return s.instance(op, nil, details)
+}
+
+// Wrap creates an instance for op caused by err. It returns nil if err is nil.
+func (s *Sentinel) Wrap(err error, op string, details map[string]any) error {
+	if err == nil {
+		return nil
+	}
+	return s.instance(op, err, details)
+}
+
+func (s *Sentinel) instance(op string, err error, details map[string]any) *Herror {
+	h := buildHerror(GetStackConfig(), 1, op, s.Category, s.message(details), err, details)
+	h.Code = s.Code
+	h.Severity = s.Severity
+	h.def = s
+	return h
+}
+
+// message renders the Template from details, or returns Message when there
+// is no template. Details pass through the package-wide Redactor first, and
+// placeholders without a matching detail are left as they are.
+func (s *Sentinel) message(details map[string]any) string {
+	if s.Template == "" {
+		return s.Message
+	}
+	shown := GetRedactor().Redact(details)
+	return placeholder.ReplaceAllStringFunc(s.Template, func(m string) string {
+		if v, ok := shown[m[1:len(m)-1]]; ok {
+			return fmt.Sprintf("%v", v)
+		}
+		return m
+	})
+}
+
+// placeholder matches the {name} placeholders of a Sentinel template.
+var placeholder = regexp.MustCompile(`\{[A-Za-z_][A-Za-z0-9_.-]*\}`)
+
+// HelpURL returns the help link of the definition h was created from, or "".
+func (h *Herror) HelpURL() string {
+	if h.def == nil {
+		return ""
+	}
+	return h.def.HelpURL
+}
+
+// definition returns the Sentinel of the outermost Herror in err's chain that
+// was created from one, or nil.
+func definition(err error) *Sentinel {
+	for _, layer := range Chain(err) {
+		if h, ok := layer.(*Herror); ok && h.def != nil {
+			return h.def
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Is reports whether h is an instance of target: a *Sentinel, or another
+// *Herror, with the same Code (and the same Category, when target has one).
+// Herrors without a Code only match themselves.
+func (h *Herror) Is(target error) bool {
+	if h.Code == "" {
+		return false
+	}
+	switch t := target.(type) {
+	case *Sentinel:
+		return t.Code == h.Code && (t.Category == "" || t.Category == h.Category)
+	case *Herror:
+		return t.Code == h.Code && (t.Category == "" || t.Category == h.Category)
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,111 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	errTestNotFound = Define("NOT_FOUND", "resource missing")
+	errTestInvalid  = DefineCategorized("INVALID", "validation", "invalid input")
+)
+
+func TestSentinel_New(t *testing.T) {
+	err := errTestNotFound.New("load user", map[string]any{"id": 7})
+	h, ok := AsHerror(err)
+	if !ok {
+		t.Fatalf("New returned %T; want *Herror", err)
+	}
+	if h.Op != "load user" || h.Message != "resource missing" || h.Code != "NOT_FOUND" || h.Details["id"] != 7 {
+		t.Errorf("instance = %+v", h)
+	}
+	if !h.HasStack() || !strings.Contains(h.StackTrace(), "sentinel_test.go") {
+		t.Errorf("instance should capture the caller's stack:\n%s", h.StackTrace())
+	}
+	if got := h.Error(); got != "operation 'load user' failed: resource missing (details: map[id:7]) [code: NOT_FOUND]" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := errTestNotFound.Error(); got != "NOT_FOUND: resource missing" {
+		t.Errorf("Sentinel.Error() = %q", got)
+	}
+	if code, ok := Code(err); !ok || code != "NOT_FOUND" {
+		t.Errorf("Code = %q, %v", code, ok)
+	}
+}
+
+func TestSentinel_Is(t *testing.T) {
+	err := errTestNotFound.New("load", nil)
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"instance", err, true},
+		{"Wrap", Wrap(err, "handler", "lookup failed"), true},
+		{"PropagateErr", PropagateErr("svc", "other", "failed", err, nil), true},
+		{"fmt wrap", fmt.Errorf("ctx: %w", err), true},
+		{"sentinel Wrap", errTestNotFound.Wrap(io.EOF, "read", nil), true},
+		{"other code", errTestInvalid.New("parse", nil), false},
+		{"plain herror", NewHerror("load", "resource missing", nil, nil), false},
+	}
+	for _, tc := range tests {
+		if got := errors.Is(tc.err, errTestNotFound); got != tc.want {
+			t.Errorf("%s: errors.Is = %v; want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// the cause stays reachable and a nil cause yields nil
+	if !errors.Is(errTestNotFound.Wrap(io.EOF, "read", nil), io.EOF) {
+		t.Error("Sentinel.Wrap should keep the cause in the chain")
+	}
+	if errTestNotFound.Wrap(nil, "read", nil) != nil {
+		t.Error("Sentinel.Wrap(nil) should be nil")
+	}
+
+	// a categorized sentinel also requires the category
+	inst, _ := AsHerror(errTestInvalid.New("parse", nil))
+	if !errors.Is(inst, errTestInvalid) {
+		t.Error("categorized instance should match its sentinel")
+	}
+	inst.Category = "io"
+	if errors.Is(inst, errTestInvalid) {
+		t.Error("category mismatch should not match")
+	}
+
+	// two instances of one sentinel match each other
+	if !errors.Is(errTestNotFound.New("a", nil), errTestNotFound.New("b", nil)) {
+		t.Error("instances with the same code should match")
+	}
+}
+
+func TestSentinel_CodeInherited(t *testing.T) {
+	err := PropagateErr("svc", "", "failed", errTestNotFound.New("load", nil), nil)
+	if code, _ := Code(err); code != "NOT_FOUND" {
+		t.Errorf("PropagateErr code = %q; want inherited", code)
+	}
+	if code, _ := Code(Wrap(err, "outer", "x")); code != "NOT_FOUND" {
+		t.Errorf("Wrap code = %q; want inherited", code)
+	}
+
+	raw, _ := json.Marshal(err)
+	decoded, derr := DecodeHerror(raw)
+	if derr != nil || decoded.Code != "NOT_FOUND" || !errors.Is(decoded, errTestNotFound) {
+		t.Errorf("code lost in JSON round trip: %v %+v", derr, decoded)
+	}
+	raw, _ = json.Marshal(NewHerror("x", "y", nil, nil))
+	if strings.Contains(string(raw), `"Code"`) {
+		t.Errorf("empty code should be omitted: %s", raw)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
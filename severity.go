@@ -0,0 +1,103 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Severity ranks how serious an error is. The zero value means the severity
+// was not set; SeverityOf then keeps looking down the chain.
+type Severity int
+
+const (
+	SeverityUnset Severity = iota
+	SeverityDebug
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+	SeverityFatal
+)
+
+var severityNames = [...]string{"", "debug", "info", "warning", "error", "critical", "fatal"}
+
+// String returns the lowercase name ("warning", "critical", ...), or "" when
+// unset.
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity parses a severity name, case-insensitively. "warn" is
+// accepted for "warning".
+func ParseSeverity(name string) (Severity, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warn" {
+		return SeverityWarning, nil
+	}
+	for i, n := range severityNames {
+		if n == name {
+			return Severity(i), nil
+		}
+	}
+	return SeverityUnset, NewCategorizedHerror("parse severity", "usage", fmt.Sprintf("unknown severity %q", name), nil, nil)
+}
+
+// MarshalText encodes the severity as its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity name.
+func (s *Severity) UnmarshalText(text []byte) error {
+	parsed, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewSeverityHerror creates a new Herror with a category and a severity.
+func NewSeverityHerror(
+	op, category string,
+	severity Severity,
+	msg string,
+	err error,
+	details map[string]any,
+) error {
+	herr := newHerror(op, category, msg, err, details)
+	herr.Severity = severity
+	return herr
+}
+
+// SeverityOf returns the first severity set in err's chain, outermost first,
+// or SeverityUnset.
+func SeverityOf(err error) Severity {
+	for _, layer := range Chain(err) {
+		if h, ok := layer.(*Herror); ok && h.Severity != SeverityUnset {
+			return h.Severity
+		}
+	}
+	return SeverityUnset
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// colorBySeverity styles text the way formatters show an error of severity s,
+// with the Theme's style for s or its Value style.
+func colorBySeverity(s Severity, text string) string {
+	return paint(GetTheme().severityStyle(s), text)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
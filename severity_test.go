@@ -0,0 +1,120 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestSeverity_StringAndParse(t *testing.T) {
+	for s := SeverityDebug; s <= SeverityFatal; s++ {
+		parsed, err := ParseSeverity(strings.ToUpper(s.String()))
+		if err != nil || parsed != s {
+			t.Errorf("ParseSeverity(%q) = %v, %v", s, parsed, err)
+		}
+	}
+	if s, _ := ParseSeverity("warn"); s != SeverityWarning {
+		t.Errorf("ParseSeverity(warn) = %v", s)
+	}
+	if _, err := ParseSeverity("loud"); err == nil {
+		t.Error("unknown severity should fail to parse")
+	}
+	if SeverityUnset.String() != "" || Severity(42).String() != "severity(42)" {
+		t.Error("unexpected String for unset or out-of-range severity")
+	}
+}
+
+func TestSeverity_ConstructorsAndInheritance(t *testing.T) {
+	err := NewSeverityHerror("probe", "net", SeverityWarning, "slow upstream", nil, nil)
+	h, _ := AsHerror(err)
+	if h.Severity != SeverityWarning || h.Category != "net" || !h.HasStack() {
+		t.Errorf("NewSeverityHerror = %+v", h)
+	}
+	if got := SeverityOf(PropagateErr("svc", "", "failed", err, nil)); got != SeverityWarning {
+		t.Errorf("PropagateErr severity = %v; want inherited warning", got)
+	}
+	if got := SeverityOf(Wrap(err, "outer", "x")); got != SeverityWarning {
+		t.Errorf("Wrap severity = %v; want inherited warning", got)
+	}
+	if SeverityOf(errors.New("plain")) != SeverityUnset {
+		t.Error("plain errors have no severity")
+	}
+
+	raw, _ := json.Marshal(h)
+	if !strings.Contains(string(raw), `"Severity":"warning"`) {
+		t.Errorf("JSON should carry the severity name: %s", raw)
+	}
+	decoded, derr := DecodeHerror(raw)
+	if derr != nil || decoded.Severity != SeverityWarning {
+		t.Errorf("severity lost in JSON round trip: %v %v", derr, decoded)
+	}
+}
+
+func TestSeverity_Formatters(t *testing.T) {
+	clearColorEnv(t)
+	warn, _ := AsHerror(NewSeverityHerror("probe", "net", SeverityWarning, "slow", nil, nil))
+	plain, _ := AsHerror(NewCategorizedHerror("probe", "net", "slow", nil, nil))
+
+	if got := SimpleColoredFormatter(warn); !strings.HasPrefix(stripANSI(got), "WARNING: ") || got == SimpleColoredFormatter(plain) {
+		t.Errorf("SimpleColoredFormatter should label and color by severity: %q", got)
+	}
+	if !strings.HasPrefix(stripANSI(SimpleColoredFormatter(plain)), "ERROR: ") {
+		t.Error("errors without severity keep the ERROR label")
+	}
+	if colorBySeverity(SeverityWarning, "x") == colorBySeverity(SeverityCritical, "x") {
+		t.Error("warning and critical should be colored differently")
+	}
+	if out := stripANSI(PseudoJSONFormatter(warn)); !strings.Contains(out, "Severity warning,") {
+		t.Errorf("PseudoJSONFormatter missing severity:\n%s", out)
+	}
+}
+
+func TestCheckErr_ExitThreshold(t *testing.T) {
+	exits := 0
+	buf := &bytes.Buffer{}
+	r := NewReporter(
+		WithWriter(buf),
+		WithFormatter(PlainFormatter),
+		WithExitFunc(func(int) { exits++ }),
+		WithExitThreshold(SeverityError),
+	)
+
+	r.CheckErr(NewSeverityHerror("probe", "net", SeverityWarning, "slow", nil, nil))
+	if exits != 0 || buf.Len() == 0 {
+		t.Errorf("warning below threshold: exits=%d output=%q; want reported without exiting", exits, buf.String())
+	}
+	r.CheckErr(errors.New("boom")) // defaults to critical
+	if exits != 1 {
+		t.Errorf("critical error should exit; exits=%d", exits)
+	}
+	r.CheckErr(errors.New("boom"), WithSeverity(SeverityInfo))
+	if exits != 1 {
+		t.Errorf("WithSeverity(info) should stay below threshold; exits=%d", exits)
+	}
+
+	if err := r.Report(NewSeverityHerror("x", "", SeverityDebug, "y", nil, nil), WithExitPolicy(PanicPolicy)); err == nil {
+		t.Error("Report below threshold should return the reported error")
+	}
+}
+
+func TestRegistry_BySeverity(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(NewSeverityHerror("a", "", SeverityWarning, "", nil, nil))
+	reg.Register(Wrap(NewSeverityHerror("b", "", SeverityWarning, "", nil, nil), "c", ""))
+	reg.Register(errors.New("plain"))
+
+	snap := reg.Snapshot()
+	if snap.BySeverity["warning"].Count != 2 || snap.BySeverity["unknown"].Count != 1 {
+		t.Errorf("BySeverity = %v", snap.BySeverity)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,168 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LogValue implements slog.LogValuer, so logging an *Herror produces a group
+// with op, message, category, cause, details and a compact stack instead of
+// the flat Error() string.
+func (h *Herror) LogValue() slog.Value {
+	attrs := herrorAttrs(h, true)
+	if h.Err != nil {
+		attrs = append(attrs, slog.String("cause", h.Err.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SlogHandler wraps another slog.Handler and expands every error attribute
+// into structured fields: the full message plus one group per Herror layer
+// found by walking the chain with AsHerror, and the raw root cause.
+//
+//	logger := slog.New(horus.NewSlogHandler(slog.NewJSONHandler(os.Stderr, nil)))
+//	logger.Error("request failed", "err", err)
+type SlogHandler struct {
+	next slog.Handler
+}
+
+// NewSlogHandler returns a SlogHandler that forwards to next.
+func NewSlogHandler(next slog.Handler) *SlogHandler {
+	return &SlogHandler{next: next}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (sh *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return sh.next.Enabled(ctx, level)
+}
+
+// Handle expands error attributes and forwards the record.
+func (sh *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(expandErrorAttr(a))
+		return true
+	})
+	return sh.next.Handle(ctx, out)
+}
+
+// WithAttrs expands error attributes and forwards them to the wrapped handler.
+func (sh *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = expandErrorAttr(a)
+	}
+	return &SlogHandler{next: sh.next.WithAttrs(expanded)}
+}
+
+// WithGroup forwards the group to the wrapped handler.
+func (sh *SlogHandler) WithGroup(name string) slog.Handler {
+	return &SlogHandler{next: sh.next.WithGroup(name)}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// expandErrorAttr replaces an error-valued attribute (at any group depth)
+// with its expanded chain.
+func expandErrorAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		members := a.Value.Group()
+		expanded := make([]slog.Attr, len(members))
+		for i, m := range members {
+			expanded[i] = expandErrorAttr(m)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(expanded...)}
+	case slog.KindAny, slog.KindLogValuer:
+		if err, ok := a.Value.Any().(error); ok && err != nil {
+			return slog.Attr{Key: a.Key, Value: errorChainValue(err)}
+		}
+	}
+	return a
+}
+
+// errorChainValue renders err as a group: "msg" holds Error(), "chain" holds
+// one group per Herror layer (outermost first, stack on the innermost only)
+// and "cause" holds the first non-Herror error below them, if any.
+func errorChainValue(err error) slog.Value {
+	var layers []*Herror
+	cause := err
+	for cause != nil {
+		h, ok := AsHerror(cause)
+		if !ok {
+			break
+		}
+		layers = append(layers, h)
+		cause = h.Err
+	}
+
+	attrs := []slog.Attr{slog.String("msg", err.Error())}
+	if len(layers) > 0 {
+		chain := make([]slog.Attr, len(layers))
+		for i, h := range layers {
+			chain[i] = slog.Attr{
+				Key:   strconv.Itoa(i),
+				Value: slog.GroupValue(herrorAttrs(h, i == len(layers)-1)...),
+			}
+		}
+		attrs = append(attrs, slog.Attr{Key: "chain", Value: slog.GroupValue(chain...)})
+	}
+	if cause != nil && len(layers) > 0 {
+		attrs = append(attrs, slog.String("cause", cause.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// herrorAttrs returns the attributes describing a single Herror layer.
+func herrorAttrs(h *Herror, withStack bool) []slog.Attr {
+	attrs := []slog.Attr{slog.String("op", h.Op)}
+	if h.Message != "" {
+		attrs = append(attrs, slog.String("message", h.Message))
+	}
+	if h.Category != "" {
+		attrs = append(attrs, slog.String("category", h.Category))
+	}
+	if h.Severity != SeverityUnset {
+		attrs = append(attrs, slog.String("severity", h.Severity.String()))
+	}
+	if details := h.redactedDetails(); len(details) > 0 {
+		keys := make([]string, 0, len(details))
+		for k := range details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		group := make([]slog.Attr, len(keys))
+		for i, k := range keys {
+			group[i] = slog.Any(k, details[k])
+		}
+		attrs = append(attrs, slog.Attr{Key: "details", Value: slog.GroupValue(group...)})
+	}
+	if withStack && h.HasStack() {
+		attrs = append(attrs, slog.Any("stack", compactStack(h)))
+	}
+	return attrs
+}
+
+// compactStack returns one "function file:line" entry per frame, with the
+// file reduced to its base name.
+func compactStack(h *Herror) []string {
+	var out []string
+	for _, frame := range h.Frames() {
+		out = append(out, fmt.Sprintf("%s %s:%d", frame.Function, filepath.Base(frame.File), frame.Line))
+	}
+	return out
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,104 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// logJSON logs a single record through handler-wrapping wrap and decodes it.
+func logJSON(t *testing.T, wrap func(slog.Handler) slog.Handler, args ...any) map[string]any {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	logger := slog.New(wrap(slog.NewJSONHandler(buf, nil)))
+	logger.Error("failed", args...)
+
+	var m map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("invalid JSON log line: %v\n%s", err, buf.String())
+	}
+	return m
+}
+
+func TestHerror_LogValue(t *testing.T) {
+	h := NewCategorizedHerror("read file", "io", "cannot read", errors.New("eof"), map[string]any{"path": "/x"})
+	m := logJSON(t, func(h slog.Handler) slog.Handler { return h }, "err", h)
+
+	got, ok := m["err"].(map[string]any)
+	if !ok {
+		t.Fatalf("err attribute is %T; want group", m["err"])
+	}
+	if got["op"] != "read file" || got["message"] != "cannot read" || got["category"] != "io" || got["cause"] != "eof" {
+		t.Errorf("LogValue fields wrong: %v", got)
+	}
+	if details, _ := got["details"].(map[string]any); details["path"] != "/x" {
+		t.Errorf("details = %v; want path=/x", got["details"])
+	}
+	if stack, _ := got["stack"].([]any); len(stack) == 0 {
+		t.Error("LogValue missing stack")
+	}
+}
+
+func TestSlogHandler_ExpandsChain(t *testing.T) {
+	root := errors.New("connection refused")
+	inner := NewCategorizedHerror("dial", "network", "dial failed", root, map[string]any{"host": "db"})
+	outer := PropagateErr("load user", "", "lookup failed", inner, nil)
+
+	wrap := func(h slog.Handler) slog.Handler { return NewSlogHandler(h) }
+	m := logJSON(t, wrap, "err", outer, "plain", errors.New("flat"), "n", 1)
+
+	got := m["err"].(map[string]any)
+	if got["msg"] != outer.Error() {
+		t.Errorf("msg = %v; want %q", got["msg"], outer.Error())
+	}
+	if got["cause"] != "connection refused" {
+		t.Errorf("cause = %v; want root cause", got["cause"])
+	}
+	chain, ok := got["chain"].(map[string]any)
+	if !ok || len(chain) != 2 {
+		t.Fatalf("chain = %v; want 2 layers", got["chain"])
+	}
+	first, second := chain["0"].(map[string]any), chain["1"].(map[string]any)
+	if first["op"] != "load user" || second["op"] != "dial" {
+		t.Errorf("layer ops = %v, %v; want load user, dial", first["op"], second["op"])
+	}
+	if _, ok := first["stack"]; ok {
+		t.Error("outer layer should not repeat the stack")
+	}
+	if _, ok := second["stack"]; !ok {
+		t.Error("innermost layer should carry the stack")
+	}
+
+	if plain := m["plain"].(map[string]any); plain["msg"] != "flat" {
+		t.Errorf("plain error = %v; want msg=flat", plain)
+	}
+	if m["n"] != float64(1) {
+		t.Errorf("non-error attribute changed: %v", m["n"])
+	}
+}
+
+func TestSlogHandler_WithAttrsAndGroups(t *testing.T) {
+	wrap := func(h slog.Handler) slog.Handler {
+		return NewSlogHandler(h).WithAttrs([]slog.Attr{slog.Any("base", NewHerror("init", "", nil, nil))})
+	}
+	m := logJSON(t, wrap, slog.Group("req", slog.Any("err", NewHerror("serve", "", nil, nil))))
+
+	base := m["base"].(map[string]any)
+	if chain := base["chain"].(map[string]any); chain["0"].(map[string]any)["op"] != "init" {
+		t.Errorf("WithAttrs error not expanded: %v", base)
+	}
+	req := m["req"].(map[string]any)
+	if chain := req["err"].(map[string]any)["chain"].(map[string]any); chain["0"].(map[string]any)["op"] != "serve" {
+		t.Errorf("grouped error not expanded: %v", req)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,95 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// StackMode selects which Herrors capture a stack trace.
+type StackMode int
+
+const (
+	// StackFull captures a stack on every Herror, including every Wrap and
+	// PropagateErr layer. This is the default.
+	StackFull StackMode = iota
+	// StackInnermost captures a stack only when the wrapped error does not
+	// already carry one, so a chain pays for runtime.Callers once.
+	StackInnermost
+	// StackOff never captures stacks.
+	StackOff
+)
+
+// DefaultStackDepth is the maximum number of frames captured when
+// StackConfig.Depth is not set.
+const DefaultStackDepth = 32
+
+// StackConfig controls stack capture. Only program counters are recorded at
+// creation time; symbolization (function, file, line) happens lazily when a
+// stack is printed, so the cost on hot paths is a single runtime.Callers.
+type StackConfig struct {
+	Mode  StackMode
+	Depth int // maximum number of frames; <= 0 means DefaultStackDepth
+	Skip  int // extra frames to skip above the horus constructor
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var stackConfig atomic.Pointer[StackConfig]
+
+func init() {
+	stackConfig.Store(&StackConfig{Depth: DefaultStackDepth})
+}
+
+// SetStackConfig replaces the package-wide stack configuration and returns
+// the previous one. It is safe to call concurrently with error creation.
+func SetStackConfig(cfg StackConfig) StackConfig {
+	return *stackConfig.Swap(&cfg)
+}
+
+// GetStackConfig returns the package-wide stack configuration.
+func GetStackConfig() StackConfig {
+	return *stackConfig.Load()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// capture records the current stack according to the configuration. skip is
+// the number of frames above capture's caller to leave out (0 keeps the
+// caller itself). cause is the error being wrapped, consulted by
+// StackInnermost.
+func (c StackConfig) capture(cause error, skip int) []uintptr {
+	switch c.Mode {
+	case StackOff:
+		return nil
+	case StackInnermost:
+		if chainHasStack(cause) {
+			return nil
+		}
+	}
+	depth := c.Depth
+	if depth <= 0 {
+		depth = DefaultStackDepth
+	}
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(2+skip+c.Skip, pcs)
+	return pcs[:n]
+}
+
+// chainHasStack reports whether any Herror in err's chain carries a stack.
+func chainHasStack(err error) bool {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if herr, ok := e.(*Herror); ok && herr.HasStack() {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,107 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestStackConfig_Modes(t *testing.T) {
+	defer SetStackConfig(SetStackConfig(StackConfig{Mode: StackOff}))
+
+	if h, _ := AsHerror(NewHerror("op", "", nil, nil)); h.HasStack() {
+		t.Error("StackOff should not capture a stack")
+	}
+
+	SetStackConfig(StackConfig{Mode: StackInnermost})
+	inner := NewHerror("inner", "", errors.New("root"), nil)
+	outer := Wrap(inner, "outer", "")
+	top := PropagateErr("top", "", "", outer, nil)
+
+	hi, _ := AsHerror(inner)
+	ho := outer.(*Herror)
+	ht := top.(*Herror)
+	if !hi.HasStack() || ho.HasStack() || ht.HasStack() {
+		t.Errorf("StackInnermost: stacks = %v, %v, %v; want only the innermost",
+			hi.HasStack(), ho.HasStack(), ht.HasStack())
+	}
+	if out := fmt.Sprintf("%+v", top); !strings.Contains(out, "horus.NewHerror\n\t") || !strings.Contains(out, "stack_test.go:") {
+		t.Errorf("%%+v should print the innermost stack:\n%s", out)
+	}
+}
+
+func TestStackConfig_DepthAndSkip(t *testing.T) {
+	defer SetStackConfig(SetStackConfig(StackConfig{Depth: 2}))
+
+	h, _ := AsHerror(NewHerror("op", "", nil, nil))
+	if len(h.Stack) != 2 {
+		t.Errorf("Depth 2 captured %d frames", len(h.Stack))
+	}
+
+	SetStackConfig(StackConfig{})
+	full, _ := AsHerror(NewHerror("op", "", nil, nil))
+	if len(full.Stack) == 0 || len(full.Stack) > DefaultStackDepth {
+		t.Errorf("Depth 0 captured %d frames; want 1..%d", len(full.Stack), DefaultStackDepth)
+	}
+
+	SetStackConfig(StackConfig{Skip: 1})
+	skipped, _ := AsHerror(NewHerror("op", "", nil, nil))
+	first := func(h *Herror) string { return strings.SplitN(h.StackTrace(), "\n", 2)[0] }
+	if got, want := first(full), "github.com/DanielRivasMD/horus.NewHerror"; got != want {
+		t.Errorf("first frame = %q; want %q", got, want)
+	}
+	if got := first(skipped); !strings.HasSuffix(got, "TestStackConfig_DepthAndSkip") {
+		t.Errorf("Skip 1 first frame = %q; want the calling test", got)
+	}
+}
+
+func TestWithStackConfig_Reporter(t *testing.T) {
+	var got *Herror
+	r := NewReporter(
+		WithWriter(&bytes.Buffer{}),
+		WithStackConfig(StackConfig{Mode: StackOff}),
+		WithExitPolicy(CallbackPolicy(func(h *Herror, _ int) { got = h })),
+	)
+	r.CheckErr(errors.New("x"))
+	if got == nil || got.HasStack() {
+		t.Error("reporter-level StackOff should not capture a stack")
+	}
+
+	// package-wide configuration is untouched
+	if h, _ := AsHerror(NewHerror("op", "", nil, nil)); !h.HasStack() {
+		t.Error("package default should still capture stacks")
+	}
+	if trace, _ := StackTrace(NewHerror("op", "", nil, nil)); !strings.Contains(trace, "TestWithStackConfig_Reporter") {
+		t.Errorf("stack should start at the caller; got:\n%s", trace)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func benchmarkChain(b *testing.B, cfg StackConfig) {
+	defer SetStackConfig(SetStackConfig(cfg))
+	root := errors.New("root")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		err := NewHerror("read", "", root, nil)
+		for range 4 {
+			err = Wrap(err, "layer", "")
+		}
+	}
+}
+
+func BenchmarkStack_Full(b *testing.B)      { benchmarkChain(b, StackConfig{Mode: StackFull}) }
+func BenchmarkStack_Depth8(b *testing.B)    { benchmarkChain(b, StackConfig{Depth: 8}) }
+func BenchmarkStack_Innermost(b *testing.B) { benchmarkChain(b, StackConfig{Mode: StackInnermost}) }
+func BenchmarkStack_Off(b *testing.B)       { benchmarkChain(b, StackConfig{Mode: StackOff}) }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,191 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/DanielRivasMD/horus/internal/ansi"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PlainTemplate reproduces PlainFormatter.
+const PlainTemplate = `{{.Op}}: {{.Message}}`
+
+// PseudoJSONTemplate reproduces PseudoJSONFormatter.
+const PseudoJSONTemplate = `{{- $w := .KeyWidth}}{{$h := .Herror -}}
+{{row $h $w "Op" .Op}}{{row $h $w "Message" .Message}}
+{{- if .Err}}{{row $h $w "Err" .ErrText}}{{end -}}
+{{if .SortedDetails}}{{key "Details"}}
+{{details $h $w}}
+{{end -}}
+{{row $h $w "Category" .Category}}{{row $h $w "Severity" .Severity.String}}
+{{- row $h $w "Code" .Code}}{{row $h $w "Help" .HelpURL}}{{chain $h}}
+{{- if .Layers}}{{key "Stack"}}
+{{range .Layers}}{{if gt (len $.Layers) 1}}  {{key .Op}}
+{{end}}{{range .Frames}}  {{print .Function "()" | function}}{{printf " %s:%d" .File .Line | location}}
+{{end}}{{if .Shared}}  {{printf "... %d frames in common with the layer below" .Shared | location}}
+{{end}}{{end}}{{end}}`
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// TemplateData is what a TemplateFormatter template is executed with. The
+// embedded *Herror exposes its fields and methods ({{.Op}}, {{.Category}},
+// {{.StackTrace}}, {{.Frames}}, {{.HelpURL}}, ...).
+type TemplateData struct {
+	*Herror
+	ErrText       string           // the cause as %v prints it; a tree (FormatTree) when it branches
+	Chain         []*Herror        // every Herror layer of the chain, outermost first
+	Layers        []TemplateLayer  // stack layers, deduplicated as in %+v
+	SortedDetails []TemplateDetail // redacted details, sorted by key
+	KeyWidth      int              // width keys are padded to in PseudoJSONTemplate
+}
+
+// TemplateLayer is one Herror of the chain with the frames it adds on top of
+// the layer below it.
+type TemplateLayer struct {
+	Op     string
+	Frames []Frame
+	Shared int // trailing frames shared with the layer below, left out of Frames
+}
+
+// TemplateDetail is one detail, its value rendered with %v.
+type TemplateDetail struct {
+	Key   string
+	Value string
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// TemplateFuncs returns the functions available to TemplateFormatter
+// templates: the colors red, green, yellow, blue, magenta, cyan and white, the
+// styles bold, dim and underline, the Theme styles key, detailkey, function
+// and location, value (style text as the values of an Herror), severity
+// (style text by a Severity), pad (left-align text to a width), indent, upper,
+// lower and join. Colors and styles are dropped under ColorNever or NO_COLOR.
+//
+// row, details and chain render parts of PseudoJSONFormatter's layout for an
+// Herror: {{row .Herror .KeyWidth "Op" .Op}} renders an aligned, wrapped
+// "key value," line (nothing when the value is empty), {{details .Herror
+// .KeyWidth}} the Details block and {{chain .Herror}} the Chain section.
+func TemplateFuncs() template.FuncMap {
+	styled := func(style Style) func(string) string {
+		return func(text string) string { return paint(style, text) }
+	}
+	themed := func(pick func(*Theme) Style) func(string) string {
+		return func(text string) string { return paint(pick(GetTheme()), text) }
+	}
+	return template.FuncMap{
+		"red":       styled(ansi.Red.Paint),
+		"green":     styled(ansi.Green.Paint),
+		"yellow":    styled(ansi.Yellow.Paint),
+		"blue":      styled(ansi.Blue.Paint),
+		"magenta":   styled(ansi.Magenta.Paint),
+		"cyan":      styled(ansi.Cyan.Paint),
+		"white":     styled(ansi.White.Paint),
+		"bold":      styled(ansi.Bold.Paint),
+		"dim":       styled(ansi.Dim.Paint),
+		"underline": styled(ansi.Underline.Paint),
+		"key":       themed(func(t *Theme) Style { return t.Key }),
+		"detailkey": themed(func(t *Theme) Style { return t.DetailKey }),
+		"function":  themed(func(t *Theme) Style { return t.Function }),
+		"location":  themed(func(t *Theme) Style { return t.Location }),
+		"value": func(h *Herror, text string) string {
+			return paint(GetTheme().valueStyle(h), text)
+		},
+		"severity": colorBySeverity,
+		"row": func(h *Herror, width int, key, value string) string {
+			p := newPseudoJSON(h, defaultPseudoJSONConfig())
+			p.row(0, width, key, value)
+			return p.b.String()
+		},
+		"details": func(h *Herror, width int) string {
+			p := newPseudoJSON(h, defaultPseudoJSONConfig())
+			p.details(width, h.redactedDetails())
+			return p.b.String()
+		},
+		"chain": func(h *Herror) string {
+			p := newPseudoJSON(h, defaultPseudoJSONConfig())
+			p.chain(h)
+			return p.b.String()
+		},
+		"pad": func(width int, s string) string {
+			return fmt.Sprintf("%-*s", width, s)
+		},
+		"indent": func(n int, s string) string {
+			prefix := strings.Repeat(" ", n)
+			return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join":  strings.Join,
+	}
+}
+
+// TemplateFormatter parses tmpl as a text/template and returns a
+// FormatterFunc that executes it with the TemplateData of each Herror. The
+// built-in templates are available by name, so a layout can extend them:
+//
+//	f, err := horus.TemplateFormatter(`{{template "plain" .}} ({{.Code}})`)
+//
+// "pseudojson" is PseudoJSONTemplate and "plain" is PlainTemplate. An error
+// while executing is rendered in place of the output, as JSONFormatter does.
+func TemplateFormatter(tmpl string) (FormatterFunc, error) {
+	t := template.New("horus").Funcs(TemplateFuncs())
+	template.Must(t.New("pseudojson").Parse(PseudoJSONTemplate))
+	template.Must(t.New("plain").Parse(PlainTemplate))
+	if _, err := t.Parse(tmpl); err != nil {
+		return nil, NewCategorizedHerror("parse template", "usage", "invalid formatter template", err, nil)
+	}
+	return func(h *Herror) string {
+		var b strings.Builder
+		if err := t.Execute(&b, newTemplateData(h)); err != nil {
+			return fmt.Sprintf("error formatting: %v", err)
+		}
+		return b.String()
+	}, nil
+}
+
+// MustTemplateFormatter is like TemplateFormatter but panics if tmpl does
+// not parse. It simplifies package-level formatter variables.
+func MustTemplateFormatter(tmpl string) FormatterFunc {
+	f, err := TemplateFormatter(tmpl)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// newTemplateData collects everything a template may show about h.
+func newTemplateData(h *Herror) TemplateData {
+	data := TemplateData{Herror: h, ErrText: causeText(h), KeyWidth: pseudoJSONKeyWidth(h)}
+	for _, layer := range Chain(h) {
+		if herr, ok := layer.(*Herror); ok {
+			data.Chain = append(data.Chain, herr)
+		}
+	}
+	for _, layer := range stackLayers(h) {
+		data.Layers = append(data.Layers, TemplateLayer{Op: layer.herr.Op, Frames: layer.frames, Shared: layer.shared})
+	}
+
+	details := h.redactedDetails()
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		data.SortedDetails = append(data.SortedDetails, TemplateDetail{Key: k, Value: fmt.Sprintf("%v", details[k])})
+	}
+	return data
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
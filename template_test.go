@@ -0,0 +1,68 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestTemplateFormatter_BuiltinsMatchFormatters(t *testing.T) {
+	def := &Sentinel{Code: "CFG", Category: "config", Severity: SeverityWarning, Message: "bad config", HelpURL: "https://example.com/CFG"}
+	inner := NewCategorizedHerror("open", "io", "cannot open", errors.New("eof"), map[string]any{"path": "/x", "password": "p"})
+	errs := []error{
+		inner,
+		Wrap(inner, "load", "load failed"),
+		def.New("parse", map[string]any{"line": 3}),
+		NewSeverityHerror("probe", "net", SeverityCritical, "down", errors.Join(errors.New("a"), errors.New("b")), nil),
+	}
+
+	pseudo := MustTemplateFormatter(PseudoJSONTemplate)
+	plain := MustTemplateFormatter(`{{template "plain" .}}`)
+	for i, err := range errs {
+		h, _ := AsHerror(err)
+		if got, want := pseudo(h), PseudoJSONFormatter(h); got != want {
+			t.Errorf("case %d: pseudojson template =\n%q\nwant\n%q", i, got, want)
+		}
+		if got, want := plain(h), PlainFormatter(h); got != want {
+			t.Errorf("case %d: plain template = %q; want %q", i, got, want)
+		}
+	}
+}
+
+func TestTemplateFormatter_Custom(t *testing.T) {
+	clearColorEnv(t)
+	f, err := TemplateFormatter(`{{upper .Op}} [{{.Category}}] {{range .SortedDetails}}{{.Key}}={{.Value}} {{end}}` +
+		`chain={{len .Chain}} frames={{if .Frames}}yes{{end}} {{"x" | red}}{{pad 4 "ab"}}|{{indent 2 "a\nb"}}`)
+	if err != nil {
+		t.Fatalf("TemplateFormatter failed: %v", err)
+	}
+	h, _ := AsHerror(Wrap(NewCategorizedHerror("read", "io", "m", nil, map[string]any{"b": 2, "a": 1, "token": "t"}), "load", "x"))
+	want := "LOAD [io] a=1 b=2 token=[REDACTED] chain=2 frames=yes \x1b[31mx\x1b[39mab  |  a\n  b"
+	if got := f(h); got != want {
+		t.Errorf("custom template =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestTemplateFormatter_Errors(t *testing.T) {
+	if _, err := TemplateFormatter(`{{.Op`); err == nil {
+		t.Error("invalid template should fail to parse")
+	}
+	f := MustTemplateFormatter(`{{.Nope}}`)
+	if got := f(&Herror{}); !strings.HasPrefix(got, "error formatting:") {
+		t.Errorf("execution error = %q", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustTemplateFormatter should panic on a bad template")
+		}
+	}()
+	MustTemplateFormatter(`{{end}}`)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
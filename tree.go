@@ -0,0 +1,135 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Walk visits err and every error reachable from it through Unwrap() error
+// and Unwrap() []error (errors.Join, MultiError), depth first and in order.
+// fn receives each error with its depth (0 for err itself); returning false
+// skips that error's children.
+func Walk(err error, fn func(err error, depth int) bool) {
+	walk(err, 0, fn)
+}
+
+func walk(err error, depth int, fn func(error, int) bool) {
+	if err == nil || !fn(err, depth) {
+		return
+	}
+	for _, child := range unwrapAll(err) {
+		walk(child, depth+1, fn)
+	}
+}
+
+// RootCauses returns every leaf of err's tree (the errors that wrap nothing),
+// in depth-first order. For a linear chain it holds the single RootCause.
+func RootCauses(err error) []error {
+	var leaves []error
+	Walk(err, func(e error, _ int) bool {
+		if len(unwrapAll(e)) == 0 {
+			leaves = append(leaves, e)
+		}
+		return true
+	})
+	return leaves
+}
+
+// unwrapAll returns the errors err wraps, supporting both Unwrap forms.
+func unwrapAll(err error) []error {
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		var children []error
+		for _, child := range u.Unwrap() {
+			if child != nil {
+				children = append(children, child)
+			}
+		}
+		return children
+	case interface{ Unwrap() error }:
+		if next := u.Unwrap(); next != nil {
+			return []error{next}
+		}
+	}
+	return nil
+}
+
+// hasBranches reports whether any error in err's tree wraps more than one error.
+func hasBranches(err error) bool {
+	branched := false
+	Walk(err, func(e error, _ int) bool {
+		if len(unwrapAll(e)) > 1 {
+			branched = true
+		}
+		return !branched
+	})
+	return branched
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// FormatTree renders err's tree one error per line, children indented below
+// their parent. Each line shows only what that error adds: an Herror its own
+// op, message, details and category; a wrapper its prefix.
+//
+//	operation 'import' failed: batch rejected
+//	├── operation 'parse' failed: name is required [category: validation]
+//	└── write /tmp/out
+//	    └── disk full
+func FormatTree(err error) string {
+	if err == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(treeLabel(err))
+	writeTree(&b, unwrapAll(err), "")
+	return b.String()
+}
+
+func writeTree(b *strings.Builder, children []error, indent string) {
+	for i, child := range children {
+		branch, next := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, next = "└── ", "    "
+		}
+		b.WriteString("\n" + indent + branch + strings.ReplaceAll(treeLabel(child), "\n", "\n"+indent+next))
+		writeTree(b, unwrapAll(child), indent+next)
+	}
+}
+
+// treeLabel describes err without the text of the errors it wraps.
+func treeLabel(err error) string {
+	if h, ok := err.(*Herror); ok {
+		return h.describe(false)
+	}
+	msg := err.Error()
+	children := unwrapAll(err)
+	switch len(children) {
+	case 0:
+		return msg
+	case 1:
+		if trimmed := strings.TrimSuffix(msg, children[0].Error()); trimmed != msg {
+			if trimmed = strings.TrimRight(trimmed, ": "); trimmed != "" {
+				return trimmed
+			}
+		}
+		return msg
+	}
+	texts := make([]string, len(children))
+	for i, child := range children {
+		texts[i] = child.Error()
+	}
+	if msg == strings.Join(texts, "\n") {
+		return fmt.Sprintf("%d errors", len(children))
+	}
+	first, _, _ := strings.Cut(msg, "\n")
+	return strings.TrimSuffix(first, ":")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,98 @@
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package horus
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func sampleTree() (err, parse, disk error) {
+	parse = errors.New("name is required")
+	disk = errors.New("disk full")
+	err = Wrap(errors.Join(
+		NewCategorizedHerror("parse", "validation", "bad row", parse, nil),
+		fmt.Errorf("write /tmp/out: %w", disk),
+	), "import", "batch rejected")
+	return err, parse, disk
+}
+
+func TestWalk(t *testing.T) {
+	err, _, _ := sampleTree()
+	var visited []string
+	Walk(err, func(e error, depth int) bool {
+		visited = append(visited, fmt.Sprintf("%d:%T", depth, e))
+		return true
+	})
+	want := "0:*horus.Herror 1:*errors.joinError 2:*horus.Herror 3:*errors.errorString 2:*fmt.wrapError 3:*errors.errorString"
+	if got := strings.Join(visited, " "); got != want {
+		t.Errorf("Walk order = %s\nwant %s", got, want)
+	}
+
+	// returning false prunes the subtree
+	count := 0
+	Walk(err, func(e error, depth int) bool {
+		count++
+		return depth < 1
+	})
+	if count != 2 {
+		t.Errorf("pruned walk visited %d errors; want 2", count)
+	}
+	Walk(nil, func(error, int) bool { t.Error("nil error visited"); return true })
+}
+
+func TestRootCauses(t *testing.T) {
+	err, parse, disk := sampleTree()
+	leaves := RootCauses(err)
+	if len(leaves) != 2 || leaves[0] != parse || leaves[1] != disk {
+		t.Errorf("RootCauses = %v", leaves)
+	}
+	if RootCause(err) != parse || RootCauseHelper(err) != parse {
+		t.Errorf("RootCause should follow the first branch, got %v", RootCause(err))
+	}
+	if got := RootCauses(io.EOF); len(got) != 1 || got[0] != io.EOF {
+		t.Errorf("RootCauses(leaf) = %v", got)
+	}
+	if RootCauses(nil) != nil {
+		t.Error("RootCauses(nil) should be nil")
+	}
+}
+
+func TestFormatTree(t *testing.T) {
+	t.Setenv("COLUMNS", "") // keep the default width
+	err, _, _ := sampleTree()
+	want := "operation 'import' failed: batch rejected [category: validation]\n" +
+		"└── 2 errors\n" +
+		"    ├── operation 'parse' failed: bad row [category: validation]\n" +
+		"    │   └── name is required\n" +
+		"    └── write /tmp/out\n" +
+		"        └── disk full"
+	if got := FormatTree(err); got != want {
+		t.Errorf("FormatTree =\n%s\nwant\n%s", got, want)
+	}
+	if !strings.HasPrefix(fmt.Sprintf("%+v", err), want+"\n") {
+		t.Errorf("%%+v should print the tree for branching chains:\n%+v", err)
+	}
+
+	// a linear chain keeps the one-line %+v header
+	linear := Wrap(io.EOF, "read", "short read")
+	if first, _, _ := strings.Cut(fmt.Sprintf("%+v", linear), "\n"); first != linear.Error() {
+		t.Errorf("linear %%+v header = %q", first)
+	}
+
+	m := NewMultiError(errors.New("a"), NewCategorizedHerror("b", "io", "", nil, nil))
+	h, _ := AsHerror(NewCategorizedHerror("batch", "io", "failed", m, nil))
+	out := stripANSI(PseudoJSONFormatter(h))
+	if !strings.Contains(out, "Err      2 errors occurred\n         ├── a\n         └── operation 'b' failed [category: io],") {
+		t.Errorf("PseudoJSONFormatter should draw the cause tree:\n%s", out)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////